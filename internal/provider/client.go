@@ -3,13 +3,26 @@ package provider
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
 )
 
 // -----------------------------------------------------------------------------
@@ -17,49 +30,207 @@ import (
 // -----------------------------------------------------------------------------
 
 const (
-	apiBaseURL   = "https://api.umbrella.com"
-	apiTokenURL  = apiBaseURL + "/auth/v2/token"
-	userAgent    = "terraform-provider-umbrella/0.1.0"
-	destListPath = "/policies/v2/organizations/%s/destinationlists"
-	tunnelPath   = "/v2/organizations/%s/secureinternetgateway/ipsec/sites"
-	samlPath     = "/v2/organizations/%s/saml"
-	rulesetPath  = "/policies/v2/organizations/%s/rulesets"
-	rulePath     = "/policies/v2/organizations/%s/rulesets/%s/rules"
+	apiBaseURL                   = "https://api.umbrella.com"
+	apiTokenURL                  = apiBaseURL + "/auth/v2/token"
+	destListPath                 = "/policies/v2/organizations/%s/destinationlists"
+	tunnelPath                   = "/v2/organizations/%s/secureinternetgateway/ipsec/sites"
+	samlPath                     = "/v2/organizations/%s/saml"
+	rulesetPath                  = "/policies/v2/organizations/%s/rulesets"
+	rulePath                     = "/policies/v2/organizations/%s/rulesets/%s/rules"
+	childOrgsPath                = "/admin/v2/managed/customers"
+	sitesPath                    = "/deployments/v2/organizations/%s/sites"
+	networksPath                 = "/deployments/v2/organizations/%s/networks"
+	internalNetworksPath         = "/deployments/v2/organizations/%s/internalnetworks"
+	internalDomainsPath          = "/deployments/v2/organizations/%s/internaldomains"
+	dnsPolicyPath                = "/policies/v2/organizations/%s/dnspolicies"
+	categoriesPath               = "/policies/v2/organizations/%s/categories"
+	contentCategorySettingsPath  = "/policies/v2/organizations/%s/contentcategorysettings"
+	applicationsPath             = "/appdiscovery/v2/organizations/%s/applications"
+	securitySettingsPath         = "/policies/v2/organizations/%s/securitysettings"
+	selectiveDecryptionListsPath = "/policies/v2/organizations/%s/selectivedecryptionlists"
+	blockPagesPath               = "/policies/v2/organizations/%s/blockpages"
+	warnPagesPath                = "/policies/v2/organizations/%s/warnpages"
+	bypassCodesPath              = "/policies/v2/organizations/%s/bypasscodes"
+	bypassUsersPath              = "/policies/v2/organizations/%s/bypassusers"
+	roamingComputersPath         = "/deployments/v2/organizations/%s/roamingcomputers"
+	tagsPath                     = "/deployments/v2/organizations/%s/tags"
+	virtualAppliancesPath        = "/deployments/v2/organizations/%s/virtualappliances"
+
+	// Page size used when walking paginated deployments lists.
+	listPageLimit = 200
+
+	// Page size for destination list entries, which Umbrella caps at 100.
+	destinationsPageLimit = 100
+
+	// Upper bound for a shared token refresh, which runs detached from the
+	// context of the caller that triggered it.
+	tokenRefreshTimeout = 30 * time.Second
+
+	defaultRequestTimeout = 15 * time.Second
+
+	// Operation timeouts used when a resource has no timeouts block. They
+	// bound the whole operation, which may span many requests (e.g. bulk
+	// destination syncs).
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 10 * time.Minute
 )
 
 // -----------------------------------------------------------------------------
 // Umbrella API client with OAuth2 token caching
 // -----------------------------------------------------------------------------
 
+// sharedTransport is used by every apiClient so parallel resource operations
+// share one keep-alive pool instead of dialling per request.
+var sharedTransport = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   32, // comfortably above Terraform's default parallelism of 10
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: 1 * time.Second,
+}
+
+// httpOptions customise how the provider reaches Umbrella, e.g. from build
+// agents behind a TLS-inspecting proxy. The zero value uses sharedTransport.
+type httpOptions struct {
+	ProxyURL           string
+	CACertPEM          []byte
+	ClientCertPEM      []byte
+	ClientKeyPEM       []byte
+	InsecureSkipVerify bool
+	Timeout            time.Duration
+}
+
+// newHTTPClient returns an *http.Client for opts. Custom proxy or TLS settings
+// get their own clone of sharedTransport; otherwise the shared pool is used.
+func newHTTPClient(opts httpOptions) (*http.Client, error) {
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = defaultRequestTimeout
+	}
+	if opts.ProxyURL == "" && len(opts.CACertPEM) == 0 && len(opts.ClientCertPEM) == 0 && !opts.InsecureSkipVerify {
+		return &http.Client{Timeout: timeout, Transport: sharedTransport}, nil
+	}
+
+	t := sharedTransport.Clone()
+	if opts.ProxyURL != "" {
+		u, err := url.Parse(opts.ProxyURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", opts.ProxyURL)
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(opts.CACertPEM) > 0 {
+		// Extend rather than replace the system roots so Umbrella stays
+		// reachable when the proxy only intercepts some traffic.
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(opts.CACertPEM) {
+			return nil, errors.New("no PEM certificates found in CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(opts.ClientCertPEM) > 0 {
+		cert, err := tls.X509KeyPair(opts.ClientCertPEM, opts.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	tlsConfig.InsecureSkipVerify = opts.InsecureSkipVerify //nolint:gosec // explicit opt-in, warned about at configure time
+	t.TLSClientConfig = tlsConfig
+
+	return &http.Client{Timeout: timeout, Transport: t}, nil
+}
+
 type apiClient struct {
 	key, secret, orgID string
 	client             *http.Client
-	token              string
-	expires            time.Time
+	userAgent          string
+
+	mu      sync.Mutex             // guards tokens
+	tokens  map[string]cachedToken // keyed by organisation ID
+	refresh singleflight.Group     // collapses concurrent token refreshes for an organisation into one request
 }
 
-func newAPIClient(ctx context.Context, key, secret, orgID string) (*apiClient, error) {
-	c := &apiClient{key: key, secret: secret, orgID: orgID, client: &http.Client{Timeout: 15 * time.Second}}
-	if err := c.refreshToken(ctx); err != nil {
-		return nil, err
+// cachedToken is a bearer token scoped to one organisation.
+type cachedToken struct {
+	value   string
+	expires time.Time
+}
+
+// orgPathPattern extracts the organisation ID from an API path.
+var orgPathPattern = regexp.MustCompile(`/organizations/([^/?]+)`)
+
+// newAPIClient builds a client without contacting Umbrella. Authentication is
+// deferred to the first API call so validate and plan work without (or with
+// not-yet-known) credentials.
+func newAPIClient(key, secret, orgID string, httpClient *http.Client, userAgent string) *apiClient {
+	return &apiClient{key: key, secret: secret, orgID: orgID, client: httpClient, userAgent: userAgent}
+}
+
+// buildUserAgent identifies the provider build and the Terraform CLI driving
+// it, so Cisco support can attribute API traffic. TF_APPEND_USER_AGENT and the
+// provider's user_agent_suffix are appended when set.
+func buildUserAgent(providerVersion, terraformVersion, suffix string) string {
+	ua := fmt.Sprintf("terraform-provider-umbrella/%s (%s/%s; %s)", providerVersion, runtime.GOOS, runtime.GOARCH, runtime.Version())
+	if terraformVersion != "" {
+		ua = fmt.Sprintf("Terraform/%s (+https://www.terraform.io) %s", terraformVersion, ua)
+	}
+	if extra := strings.TrimSpace(os.Getenv("TF_APPEND_USER_AGENT")); extra != "" {
+		ua += " " + extra
 	}
-	return c, nil
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		ua += " " + suffix
+	}
+	return ua
 }
 
-func (c *apiClient) refreshToken(ctx context.Context) error {
+// refreshToken fetches and caches a token for orgID. Tokens for a child
+// organisation are requested with the X-Umbrella-OrgId header, which scopes
+// the parent's MSP / Multi-Org credentials to that organisation.
+func (c *apiClient) refreshToken(ctx context.Context, orgID string) error {
+	if c.key == "" || c.secret == "" {
+		return errors.New("API credentials are not known yet; api_key and api_secret must be set before resources can be read or changed")
+	}
 	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, apiTokenURL, strings.NewReader("grant_type=client_credentials"))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("User-Agent", c.userAgent)
 	basic := base64.StdEncoding.EncodeToString([]byte(c.key + ":" + c.secret))
 	req.Header.Set("Authorization", "Basic "+basic)
+	if orgID != c.orgID {
+		req.Header.Set("X-Umbrella-OrgId", orgID)
+	}
 
+	tflog.SubsystemDebug(ctx, logSubsystem, "requesting OAuth token", map[string]interface{}{
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+	})
+	start := time.Now()
 	resp, err := c.client.Do(req)
 	if err != nil {
+		tflog.SubsystemError(ctx, logSubsystem, "OAuth token request failed", map[string]interface{}{"error": err.Error()})
 		return err
 	}
-	defer resp.Body.Close()
+	defer drainAndClose(resp.Body)
+	tflog.SubsystemDebug(ctx, logSubsystem, "OAuth token response", map[string]interface{}{
+		"http_status": resp.StatusCode,
+		"latency_ms":  time.Since(start).Milliseconds(),
+		"request_id":  resp.Header.Get(requestIDHeader),
+	})
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("token request failed: %s", resp.Status)
+		return fmt.Errorf("authentication failed: token request returned %s", resp.Status)
 	}
 	var data struct {
 		AccessToken string `json:"access_token"`
@@ -71,23 +242,286 @@ func (c *apiClient) refreshToken(ctx context.Context) error {
 	if data.AccessToken == "" {
 		return errors.New("no access_token returned")
 	}
-	c.token = data.AccessToken
-	c.expires = time.Now().Add(time.Duration(data.ExpiresIn-60) * time.Second) // refresh 1 min early
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tokens == nil {
+		c.tokens = map[string]cachedToken{}
+	}
+	c.tokens[orgID] = cachedToken{
+		value:   data.AccessToken,
+		expires: time.Now().Add(time.Duration(data.ExpiresIn-60) * time.Second), // refresh 1 min early
+	}
 	return nil
 }
 
-func (c *apiClient) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
-	if time.Now().After(c.expires) {
-		if err := c.refreshToken(ctx); err != nil {
-			return nil, err
+// cachedTokenFor returns the cached token for orgID, if it is still usable
+// and is not stale (a token the API just rejected).
+func (c *apiClient) cachedTokenFor(orgID, stale string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := c.tokens[orgID]
+	if t.value == "" || t.value == stale || !time.Now().Before(t.expires) {
+		return "", false
+	}
+	return t.value, true
+}
+
+// pathOrgID returns the organisation an API path addresses, or the
+// provider's org_id for paths outside any organisation.
+func (c *apiClient) pathOrgID(path string) string {
+	if m := orgPathPattern.FindStringSubmatch(path); m != nil {
+		return m[1]
+	}
+	return c.orgID
+}
+
+// resolveOrgID returns the organisation a resource lives in: its own org_id
+// override when set, otherwise the provider's org_id.
+func (c *apiClient) resolveOrgID(override types.String) string {
+	if override.IsNull() || override.IsUnknown() || override.ValueString() == "" {
+		return c.orgID
+	}
+	return override.ValueString()
+}
+
+// accessToken returns a usable bearer token for orgID. The cached token is
+// returned unless it has expired or equals stale (a token the API just
+// rejected). Concurrent callers needing a new token for the same
+// organisation share a single refresh request.
+func (c *apiClient) accessToken(ctx context.Context, orgID, stale string) (string, error) {
+	if token, ok := c.cachedTokenFor(orgID, stale); ok {
+		return token, nil
+	}
+
+	v, err, _ := c.refresh.Do(orgID, func() (interface{}, error) {
+		// Another caller may have refreshed while we waited for the lock.
+		if token, ok := c.cachedTokenFor(orgID, stale); ok {
+			return token, nil
 		}
+		// The refresh is shared by every waiting caller, so it must not be
+		// cancelled with the first caller's operation.
+		refreshCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), tokenRefreshTimeout)
+		defer cancel()
+		if err := c.refreshToken(refreshCtx, orgID); err != nil {
+			return "", err
+		}
+		token, _ := c.cachedTokenFor(orgID, "")
+		return token, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// do sends an authenticated request. A 401 is retried once with a freshly
+// issued token in case the cached one was revoked or expired early. ctx must
+// come from logContext.
+func (c *apiClient) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	orgID := c.pathOrgID(path)
+	token, err := c.accessToken(ctx, orgID, "")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.send(ctx, method, path, body, token, 0)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	drainAndClose(resp.Body)
+
+	token, err = c.accessToken(ctx, orgID, token)
+	if err != nil {
+		return nil, err
 	}
-	url := apiBaseURL + path
-	req, _ := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("User-Agent", userAgent)
+	return c.send(ctx, method, path, body, token, 1)
+}
+
+func (c *apiClient) send(ctx context.Context, method, path string, body []byte, token string, retry int) (*http.Response, error) {
+	req, _ := http.NewRequestWithContext(ctx, method, apiBaseURL+path, bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("User-Agent", c.userAgent)
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
-	return c.client.Do(req)
+
+	fields := map[string]interface{}{
+		"http_method": method,
+		"http_path":   path,
+		"retry_count": retry,
+	}
+	tflog.SubsystemDebug(ctx, logSubsystem, "sending API request", fields)
+	if body != nil {
+		tflog.SubsystemTrace(ctx, logSubsystem, "API request body", map[string]interface{}{
+			"http_method": method,
+			"http_path":   path,
+			"body":        logBody(body),
+		})
+	}
+
+	start := time.Now()
+	resp, err := c.client.Do(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemError(ctx, logSubsystem, "API request failed", fields)
+		return nil, err
+	}
+	fields["http_status"] = resp.StatusCode
+	fields["request_id"] = resp.Header.Get(requestIDHeader)
+	tflog.SubsystemDebug(ctx, logSubsystem, "received API response", fields)
+	return resp, nil
+}
+
+// doJSON sends in (when non-nil) as a JSON body and decodes a 2xx response
+// into out (when non-nil). The response body is always drained and closed so
+// the connection goes back to the pool. Non-2xx responses are returned as
+// *apiError.
+func (c *apiClient) doJSON(ctx context.Context, method, path string, in, out interface{}) error {
+	ctx = logContext(ctx)
+	var body []byte
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("encode %s %s: %w", method, path, err)
+		}
+		body = b
+	}
+	resp, err := c.do(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(resp.Body)
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read %s %s: %w", method, path, err)
+	}
+	tflog.SubsystemTrace(ctx, logSubsystem, "API response body", map[string]interface{}{
+		"http_method": method,
+		"http_path":   path,
+		"body":        logBody(b),
+	})
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("decode %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// drainAndClose discards what is left of a response body before closing it;
+// an unread body prevents the transport from reusing the connection.
+func drainAndClose(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, 1<<20))
+	_ = body.Close()
+}
+
+// -----------------------------------------------------------------------------
+// Error classification
+// -----------------------------------------------------------------------------
+
+// apiError is returned for non-2xx responses so callers can tell an object
+// that is gone (404) apart from transport or server failures.
+type apiError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	msg := fmt.Sprintf("%s %s: HTTP %s", e.Method, e.Path, e.Status)
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+// isNotFound reports whether err is an Umbrella 404. Only this case should
+// remove a resource from state; anything else is surfaced as an error.
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// checkResponse returns an *apiError when resp does not carry a 2xx status.
+// The first KiB of the body is kept for the error message.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return &apiError{
+		Method:     resp.Request.Method,
+		Path:       resp.Request.URL.Path,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       strings.TrimSpace(string(b)),
+	}
+}
+
+// getAllPages walks a paginated list endpoint (page/limit query parameters)
+// and returns every item. path must not already carry a query string.
+func getAllPages[T any](ctx context.Context, c *apiClient, path string) ([]T, error) {
+	return getPages[T](ctx, c, path, listPageLimit)
+}
+
+// getPages is getAllPages for endpoints with their own maximum page size.
+func getPages[T any](ctx context.Context, c *apiClient, path string, limit int) ([]T, error) {
+	var all []T
+	for page := 1; ; page++ {
+		var items []T
+		if err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf("%s?page=%d&limit=%d", path, page, limit), nil, &items); err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(items) < limit {
+			return all, nil
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Logging
+// -----------------------------------------------------------------------------
+
+// logSubsystem is the tflog subsystem for API traffic. Its level follows
+// TF_LOG_PROVIDER (or TF_LOG); bodies are only logged at TRACE.
+const (
+	logSubsystem    = "umbrella_api"
+	requestIDHeader = "X-Request-Id"
+
+	// Bodies longer than this (e.g. base64 block page logos) are truncated
+	// in logs.
+	maxLoggedBody = 4 << 10
+)
+
+// sensitiveJSONField matches secret-bearing JSON members inside logged bodies.
+var sensitiveJSONField = regexp.MustCompile(`"(preSharedKey|api_secret|apiSecret|client_secret|access_token|code)"\s*:\s*"(?:[^"\\]|\\.)*"`)
+
+// logContext scopes ctx to the API logging subsystem with secrets masked in
+// every field. It is applied once per API call, in doJSON.
+func logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem)
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, logSubsystem, "api_secret", "preSharedKey", "access_token", "code")
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, logSubsystem, sensitiveJSONField)
+	return ctx
+}
+
+// logBody prepares a request or response body for logging: secret members
+// are masked first, so truncation cannot leave part of a secret unmatched,
+// and anything past maxLoggedBody is cut.
+func logBody(b []byte) string {
+	s := sensitiveJSONField.ReplaceAllString(string(b), `"$1":"***"`)
+	if len(s) > maxLoggedBody {
+		return fmt.Sprintf("%s... (%d bytes truncated)", s[:maxLoggedBody], len(s)-maxLoggedBody)
+	}
+	return s
 }
@@ -0,0 +1,744 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// testAPI is a local stand-in for Umbrella: /auth/v2/token issues tokens
+// "tok-1", "tok-2", ... and everything else goes to the handler under test.
+type testAPI struct {
+	server     *httptest.Server
+	client     *apiClient
+	tokenCalls atomic.Int32
+
+	// tokenDelay widens the window in which concurrent callers pile up on a
+	// refresh; tokenGate, when set, blocks token responses until closed.
+	tokenDelay time.Duration
+	tokenGate  chan struct{}
+	tokenSeen  chan struct{}
+
+	tokenMu        sync.Mutex
+	tokenOrgs      map[string]string // issued token -> its X-Umbrella-OrgId header
+	tokenUserAgent string            // User-Agent of the last token request
+}
+
+// newTestAPI starts the server; configure, when given, adjusts it first.
+func newTestAPI(t *testing.T, handler http.HandlerFunc, configure ...func(*httptest.Server)) *testAPI {
+	t.Helper()
+	api := &testAPI{tokenSeen: make(chan struct{}, 1), tokenOrgs: map[string]string{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/v2/token", func(w http.ResponseWriter, r *http.Request) {
+		n := api.tokenCalls.Add(1)
+		select {
+		case api.tokenSeen <- struct{}{}:
+		default:
+		}
+		if api.tokenGate != nil {
+			<-api.tokenGate
+		}
+		time.Sleep(api.tokenDelay)
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") == "" {
+			http.Error(w, "bad token request", http.StatusBadRequest)
+			return
+		}
+		token := fmt.Sprintf("tok-%d", n)
+		api.tokenMu.Lock()
+		api.tokenOrgs[token] = r.Header.Get("X-Umbrella-OrgId")
+		api.tokenUserAgent = r.UserAgent()
+		api.tokenMu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": token,
+			"expires_in":   3600,
+		})
+	})
+	mux.Handle("/", handler)
+	api.server = httptest.NewUnstartedServer(mux)
+	for _, fn := range configure {
+		fn(api.server)
+	}
+	api.server.Start()
+	t.Cleanup(api.server.Close)
+
+	api.client = newAPIClient("key", "secret", "1234", &http.Client{
+		Transport: &redirectTransport{target: api.server.URL, next: api.server.Client().Transport},
+	}, "terraform-provider-umbrella/test")
+	return api
+}
+
+// tokenOrg returns the X-Umbrella-OrgId header the bearer token of r was
+// requested with; it is empty for tokens of the credentials' own org.
+func (a *testAPI) tokenOrg(r *http.Request) string {
+	a.tokenMu.Lock()
+	defer a.tokenMu.Unlock()
+	return a.tokenOrgs[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+}
+
+// redirectTransport sends every request to target instead of the Umbrella
+// host in its URL, so the client under test runs unmodified.
+type redirectTransport struct {
+	target string
+	next   http.RoundTripper
+}
+
+func (rt *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target, err := url.Parse(rt.target)
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host, req.Host = target.Scheme, target.Host, ""
+	return rt.next.RoundTrip(req)
+}
+
+// expireToken makes the cached tokens look expired, as they would after an
+// hour.
+func (a *testAPI) expireToken() {
+	a.client.mu.Lock()
+	for org, t := range a.client.tokens {
+		t.expires = time.Now().Add(-time.Second)
+		a.client.tokens[org] = t
+	}
+	a.client.mu.Unlock()
+}
+
+// hammer calls fn from n goroutines at once and returns the errors they saw.
+func hammer(n int, fn func() error) []error {
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+		errs  = make([]error, n)
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = fn()
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	return errs
+}
+
+func okHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"ok":true}`))
+}
+
+func TestDoSharesOneTokenRefreshPerExpiry(t *testing.T) {
+	api := newTestAPI(t, okHandler)
+	api.tokenDelay = 50 * time.Millisecond
+
+	get := func() error {
+		return api.client.doJSON(context.Background(), http.MethodGet, "/ping", nil, nil)
+	}
+
+	for round := 1; round <= 3; round++ {
+		for i, err := range hammer(50, get) {
+			if err != nil {
+				t.Fatalf("round %d, caller %d: %v", round, i, err)
+			}
+		}
+		if got := api.tokenCalls.Load(); got != int32(round) {
+			t.Fatalf("after round %d: %d token requests, want %d", round, got, round)
+		}
+		api.expireToken()
+	}
+}
+
+func TestDoRetriesOnceWithForcedRefreshOn401(t *testing.T) {
+	var requests atomic.Int32
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		// The first token is "revoked" server-side before it expires.
+		if r.Header.Get("Authorization") == "Bearer tok-1" {
+			http.Error(w, "token revoked", http.StatusUnauthorized)
+			return
+		}
+		okHandler(w, r)
+	})
+	api.tokenDelay = 20 * time.Millisecond
+
+	const callers = 50
+	errs := hammer(callers, func() error {
+		return api.client.doJSON(context.Background(), http.MethodGet, "/ping", nil, nil)
+	})
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("caller %d: %v", i, err)
+		}
+	}
+	if got := api.tokenCalls.Load(); got != 2 {
+		t.Errorf("%d token requests, want 2 (initial and one forced refresh)", got)
+	}
+	if got := requests.Load(); got > 2*callers {
+		t.Errorf("%d API requests for %d callers, want at most one retry each", got, callers)
+	}
+}
+
+func TestDoDoesNotRetryMoreThanOnce(t *testing.T) {
+	var requests atomic.Int32
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "nope", http.StatusUnauthorized)
+	})
+
+	err := api.client.doJSON(context.Background(), http.MethodGet, "/ping", nil, nil)
+	if err == nil {
+		t.Fatal("expected an error for a persistent 401")
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("%d API requests, want 2", got)
+	}
+	if got := api.tokenCalls.Load(); got != 2 {
+		t.Errorf("%d token requests, want 2", got)
+	}
+}
+
+func TestTokenRefreshSurvivesCancelledCaller(t *testing.T) {
+	api := newTestAPI(t, okHandler)
+	api.tokenGate = make(chan struct{})
+
+	// The first caller starts the refresh and is then cancelled.
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstDone := make(chan error, 1)
+	go func() {
+		firstDone <- api.client.doJSON(firstCtx, http.MethodGet, "/ping", nil, nil)
+	}()
+	<-api.tokenSeen
+
+	// A second caller joins the refresh that is already in flight.
+	secondDone := make(chan error, 1)
+	go func() {
+		secondDone <- api.client.doJSON(context.Background(), http.MethodGet, "/ping", nil, nil)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancelFirst()
+	time.Sleep(20 * time.Millisecond)
+	close(api.tokenGate)
+
+	if err := <-secondDone; err != nil {
+		t.Fatalf("second caller failed because the first was cancelled: %v", err)
+	}
+	if err := <-firstDone; err == nil {
+		t.Log("first caller completed before noticing its cancellation")
+	}
+	if got := api.tokenCalls.Load(); got != 1 {
+		t.Errorf("%d token requests, want 1", got)
+	}
+}
+
+func TestTokensAreCachedPerOrganisation(t *testing.T) {
+	api := newTestAPI(t, okHandler)
+	api.tokenDelay = 20 * time.Millisecond
+
+	var n atomic.Int32
+	errs := hammer(40, func() error {
+		org := []string{"1234", "5678"}[n.Add(1)%2]
+		return api.client.doJSON(context.Background(), http.MethodGet, fmt.Sprintf(destListPath, org), nil, nil)
+	})
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("caller %d: %v", i, err)
+		}
+	}
+
+	api.tokenMu.Lock()
+	defer api.tokenMu.Unlock()
+	var orgs []string
+	for _, org := range api.tokenOrgs {
+		orgs = append(orgs, org)
+	}
+	sort.Strings(orgs)
+	if !reflect.DeepEqual(orgs, []string{"", "5678"}) {
+		t.Errorf("tokens requested for X-Umbrella-OrgId %q, want one for the provider's org and one for 5678", orgs)
+	}
+}
+
+// TestLogsNeverContainSecrets runs a tunnel (which carries a pre-shared key)
+// through its lifecycle with TRACE logging captured, including a token
+// refresh, and checks no credential reaches the log.
+func TestLogsNeverContainSecrets(t *testing.T) {
+	const (
+		apiSecret = "api-secret-7f3a9c"
+		psk       = "psk-91c2e4d0"
+	)
+	f := newFakeUmbrella(t)
+	f.api.client.secret = apiSecret
+	var logs bytes.Buffer
+	h := newTFHarness(t, f.api.client)
+	h.ctx = tflogtest.RootLogger(context.Background(), &logs)
+
+	r := h.resource("umbrella_tunnel")
+	attrs := map[string]interface{}{
+		"name": "branch", "site_origin_id": 1, "device_ip": "198.51.100.7",
+		"pre_shared_key": psk, "local_networks": []string{"10.1.0.0/16"},
+	}
+	r.apply("create", r.config(attrs))
+	f.api.expireToken()
+	attrs["pre_shared_key"] = psk + "-rotated"
+	r.apply("rotate key", r.config(attrs))
+	r.destroy("destroy")
+
+	out := logs.String()
+	if !strings.Contains(out, "API request body") || !strings.Contains(out, "requesting OAuth token") {
+		t.Fatalf("API traffic was not logged at TRACE:\n%s", out)
+	}
+	for name, secret := range map[string]string{
+		"api_secret":           apiSecret,
+		"basic credentials":    base64.StdEncoding.EncodeToString([]byte("key:" + apiSecret)),
+		"bearer token":         "tok-",
+		"Authorization header": "Authorization",
+		"pre-shared key":       psk,
+	} {
+		if strings.Contains(out, secret) {
+			t.Errorf("%s found in logs", name)
+		}
+	}
+}
+
+func TestLoggedBodiesAreTruncated(t *testing.T) {
+	logo := strings.Repeat("A", 1<<20) // a 1 MiB base64 image
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// A secret longer than the limit must be masked before truncation
+		// cuts off the closing quote the mask relies on.
+		_, _ = w.Write([]byte(`{"preSharedKey":"psk-` + logo[:2*maxLoggedBody] + `","logo":"` + logo + `"}`))
+	})
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+
+	var out map[string]interface{}
+	body := map[string]string{"logo": logo}
+	if err := api.client.doJSON(ctx, http.MethodPut, "/blockpage", body, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bodies int
+	for _, e := range entries {
+		b, ok := e["body"].(string)
+		if !ok {
+			continue
+		}
+		bodies++
+		if len(b) > maxLoggedBody+64 {
+			t.Errorf("%s: logged body is %d bytes, want at most about %d", e["@message"], len(b), maxLoggedBody)
+		}
+		if strings.Contains(b, "psk-") {
+			t.Errorf("%s: secret member partly logged", e["@message"])
+		}
+	}
+	if bodies != 2 {
+		t.Errorf("%d bodies logged, want the request and the response", bodies)
+	}
+}
+
+// connTracker records the server-side state of every connection.
+type connTracker struct {
+	mu     sync.Mutex
+	states map[net.Conn]http.ConnState
+	opened int
+}
+
+func (ct *connTracker) track(c net.Conn, state http.ConnState) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	if state == http.StateNew {
+		ct.opened++
+	}
+	ct.states[c] = state
+}
+
+// waitFor polls until every connection is in one of want, as state changes
+// on the server side lag slightly behind the client finishing a response.
+func (ct *connTracker) waitFor(t *testing.T, want ...http.ConnState) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		ct.mu.Lock()
+		var bad []string
+		for c, s := range ct.states {
+			ok := false
+			for _, w := range want {
+				ok = ok || s == w
+			}
+			if !ok {
+				bad = append(bad, fmt.Sprintf("%s: %s", c.RemoteAddr(), s))
+			}
+		}
+		ct.mu.Unlock()
+		if len(bad) == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("connections not %v: %s", want, strings.Join(bad, ", "))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDoJSONReleasesConnections(t *testing.T) {
+	large := strings.Repeat("x", 64<<10)
+	tracker := &connTracker{states: map[net.Conn]http.ConnState{}}
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/items":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":1,"name":"a"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/items/1":
+			_, _ = w.Write([]byte(`{"id":1,"name":"a"}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/items/1":
+			// A large body nobody decodes must still be drained.
+			_, _ = w.Write([]byte(`{"id":1,"padding":"` + large + `"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/items/1":
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/empty":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/broken":
+			http.Error(w, large, http.StatusInternalServerError)
+		default:
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+		}
+	}, func(s *httptest.Server) { s.Config.ConnState = tracker.track })
+
+	ctx := context.Background()
+	for i := 0; i < 20; i++ {
+		var item struct {
+			ID int64 `json:"id"`
+		}
+		if err := api.client.doJSON(ctx, http.MethodPost, "/items", map[string]string{"name": "a"}, &item); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		if err := api.client.doJSON(ctx, http.MethodGet, "/items/1", nil, &item); err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		if err := api.client.doJSON(ctx, http.MethodPut, "/items/1", map[string]string{"name": "b"}, nil); err != nil {
+			t.Fatalf("update: %v", err)
+		}
+		if err := api.client.doJSON(ctx, http.MethodDelete, "/items/1", nil, nil); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+		if err := api.client.doJSON(ctx, http.MethodGet, "/empty", nil, &item); err != nil {
+			t.Fatalf("empty body: %v", err)
+		}
+		if err := api.client.doJSON(ctx, http.MethodGet, "/items/2", nil, &item); !isNotFound(err) {
+			t.Fatalf("missing item: got %v, want a 404", err)
+		}
+		if err := api.client.doJSON(ctx, http.MethodGet, "/broken", nil, &item); err == nil || isNotFound(err) {
+			t.Fatalf("server error: got %v, want a non-404 error", err)
+		}
+	}
+
+	// Every response went back to the pool, so one connection served it all.
+	tracker.waitFor(t, http.StateIdle)
+	tracker.mu.Lock()
+	opened := tracker.opened
+	tracker.mu.Unlock()
+	if opened != 1 {
+		t.Errorf("%d connections opened, want 1 reused connection", opened)
+	}
+
+	api.server.Client().CloseIdleConnections()
+	tracker.waitFor(t, http.StateClosed)
+}
+
+// TestResourceLifecyclesReleaseConnections runs every resource through
+// create, refresh, update and delete over sharedTransport, the transport the
+// provider really uses, and checks no connection is left busy afterwards.
+func TestResourceLifecyclesReleaseConnections(t *testing.T) {
+	for typeName, tc := range resourceTestCases {
+		typeName, tc := typeName, tc
+		t.Run(typeName, func(t *testing.T) {
+			tracker := &connTracker{states: map[net.Conn]http.ConnState{}}
+			f := newFakeUmbrella(t, func(s *httptest.Server) { s.Config.ConnState = tracker.track })
+			f.api.client.client = &http.Client{Transport: &redirectTransport{target: f.api.server.URL, next: sharedTransport}}
+			if tc.seed != nil {
+				tc.seed(f)
+			}
+			r := newTFHarness(t, f.api.client).resource(typeName)
+
+			config := r.config(tc.required)
+			empty := emptyOptionals(r.schema.Block)
+			for _, name := range tc.rejectEmpty {
+				deleteAttribute(empty, name)
+			}
+			for name, v := range tc.required {
+				empty[name] = v
+			}
+			r.apply("create", config)
+			r.expectNoChanges("after create", config)
+			r.apply("update", r.config(empty))
+			r.destroy("destroy")
+
+			// Sequential requests reuse one pooled connection; a body left
+			// unread or unclosed would force a new dial for the next call.
+			tracker.waitFor(t, http.StateIdle)
+			tracker.mu.Lock()
+			opened := tracker.opened
+			tracker.mu.Unlock()
+			if opened != 1 {
+				t.Errorf("%d connections opened, want 1 reused connection", opened)
+			}
+
+			sharedTransport.CloseIdleConnections()
+			tracker.waitFor(t, http.StateClosed)
+		})
+	}
+}
+
+// testCA is a throwaway certificate authority for TLS tests.
+type testCA struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	serial  int64
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	ca := &testCA{}
+	ca.cert, ca.key, ca.certPEM = ca.issue(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "umbrella-provider test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	})
+	return ca
+}
+
+// issue signs tmpl with the CA (or self-signs when the CA has no key yet).
+func (ca *testCA) issue(t *testing.T, tmpl *x509.Certificate) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca.serial++
+	tmpl.SerialNumber = big.NewInt(ca.serial)
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	parent, signer := tmpl, key
+	if ca.key != nil {
+		parent, signer = ca.cert, ca.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// leaf issues a certificate for usage, returning it as PEM pair and as a
+// tls.Certificate.
+func (ca *testCA) leaf(t *testing.T, name string, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte, pair tls.Certificate) {
+	t.Helper()
+	_, key, certPEM := ca.issue(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: name},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:    []string{"localhost"},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{usage},
+	})
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	pair, err = tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM, keyPEM, pair
+}
+
+func (ca *testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// newTLSServer starts an HTTPS server whose certificate is issued by ca.
+// With clientCAs set it requires a client certificate issued by them.
+func newTLSServer(t *testing.T, ca *testCA, clientCAs *x509.CertPool) *httptest.Server {
+	t.Helper()
+	_, _, pair := ca.leaf(t, "127.0.0.1", x509.ExtKeyUsageServerAuth)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(okHandler))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{pair}, MinVersion: tls.VersionTLS12}
+	if clientCAs != nil {
+		srv.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+		srv.TLS.ClientCAs = clientCAs
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func getStatus(client *http.Client, url string) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	drainAndClose(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func TestNewHTTPClientDefaults(t *testing.T) {
+	c, err := newHTTPClient(httpOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Transport != sharedTransport {
+		t.Error("plain configuration should use the shared transport")
+	}
+	if c.Timeout != defaultRequestTimeout {
+		t.Errorf("timeout %s, want %s", c.Timeout, defaultRequestTimeout)
+	}
+
+	c, err = newHTTPClient(httpOptions{Timeout: 90 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Timeout != 90*time.Second {
+		t.Errorf("timeout %s, want 90s", c.Timeout)
+	}
+}
+
+func TestNewHTTPClientCustomCA(t *testing.T) {
+	ca := newTestCA(t)
+	srv := newTLSServer(t, ca, nil)
+
+	plain, err := newHTTPClient(httpOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = getStatus(plain, srv.URL)
+	var unknownAuthority x509.UnknownAuthorityError
+	if err == nil || !errors.As(err, &unknownAuthority) {
+		t.Fatalf("without the CA: got %v, want an unknown authority error", err)
+	}
+
+	trusting, err := newHTTPClient(httpOptions{CACertPEM: ca.certPEM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := getStatus(trusting, srv.URL); err != nil {
+		t.Fatalf("with the CA: %v", err)
+	}
+	if trusting.Transport == sharedTransport {
+		t.Error("custom TLS settings must not modify the shared transport")
+	}
+}
+
+func TestNewHTTPClientMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	srv := newTLSServer(t, ca, ca.pool())
+
+	noCert, err := newHTTPClient(httpOptions{CACertPEM: ca.certPEM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := getStatus(noCert, srv.URL); err == nil {
+		t.Fatal("server requiring a client certificate accepted a client without one")
+	}
+
+	certPEM, keyPEM, _ := ca.leaf(t, "terraform", x509.ExtKeyUsageClientAuth)
+	withCert, err := newHTTPClient(httpOptions{CACertPEM: ca.certPEM, ClientCertPEM: certPEM, ClientKeyPEM: keyPEM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := getStatus(withCert, srv.URL); err != nil {
+		t.Fatalf("with client certificate: %v", err)
+	}
+}
+
+func TestNewHTTPClientInsecureSkipVerify(t *testing.T) {
+	srv := newTLSServer(t, newTestCA(t), nil)
+
+	c, err := newHTTPClient(httpOptions{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := getStatus(c, srv.URL); err != nil {
+		t.Fatalf("insecure_skip_verify should accept an untrusted certificate: %v", err)
+	}
+}
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	var proxied atomic.Value
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Store(r.URL.String())
+		okHandler(w, r)
+	}))
+	t.Cleanup(proxy.Close)
+
+	c, err := newHTTPClient(httpOptions{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The target does not resolve, so only the proxy can answer.
+	if err := getStatus(c, "http://api.umbrella.invalid/ping"); err != nil {
+		t.Fatalf("request through proxy: %v", err)
+	}
+	if got, _ := proxied.Load().(string); got != "http://api.umbrella.invalid/ping" {
+		t.Errorf("proxy saw %q, want the absolute target URL", got)
+	}
+}
+
+func TestNewHTTPClientInvalidOptions(t *testing.T) {
+	ca := newTestCA(t)
+	certPEM, _, _ := ca.leaf(t, "terraform", x509.ExtKeyUsageClientAuth)
+	_, otherKeyPEM, _ := ca.leaf(t, "other", x509.ExtKeyUsageClientAuth)
+
+	for name, opts := range map[string]httpOptions{
+		"proxy without scheme":    {ProxyURL: "proxy.example.com:8080"},
+		"unparsable proxy":        {ProxyURL: "http://[::1"},
+		"CA bundle without PEM":   {CACertPEM: []byte("not a certificate")},
+		"mismatched client key":   {ClientCertPEM: certPEM, ClientKeyPEM: otherKeyPEM},
+		"client cert without PEM": {ClientCertPEM: []byte("junk"), ClientKeyPEM: []byte("junk")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := newHTTPClient(opts); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
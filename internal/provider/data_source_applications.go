@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Data source: umbrella_applications
+// -----------------------------------------------------------------------------
+
+type applicationsDataSource struct{ client *apiClient }
+
+type applicationsModel struct {
+	ID           types.String           `tfsdk:"id"`
+	OrgID        types.String           `tfsdk:"org_id"`
+	NameFilter   types.String           `tfsdk:"name_filter"`
+	Category     types.String           `tfsdk:"category"`
+	MinRiskScore types.Int64            `tfsdk:"min_risk_score"`
+	MaxRiskScore types.Int64            `tfsdk:"max_risk_score"`
+	Applications []applicationDataModel `tfsdk:"applications"`
+}
+
+type applicationDataModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Category  types.String `tfsdk:"category"`
+	RiskScore types.Int64  `tfsdk:"risk_score"`
+}
+
+// application is an entry of the App Discovery application catalogue.
+type application struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Category  string `json:"category"`
+	RiskScore int64  `json:"riskScore"`
+}
+
+func NewApplicationsDataSource() datasource.DataSource { return &applicationsDataSource{} }
+
+func (d *applicationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "umbrella_applications"
+}
+
+func (d *applicationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*apiClient)
+}
+
+func (d *applicationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Applications known to Umbrella App Discovery, for use in umbrella_rule.applications",
+		Attributes: map[string]schema.Attribute{
+			"id":             schema.StringAttribute{Computed: true, Description: "Organisation ID"},
+			"org_id":         dataSourceOrgIDAttribute(),
+			"name_filter":    schema.StringAttribute{Optional: true, Description: "Only return applications whose name contains this string (case-insensitive)"},
+			"category":       schema.StringAttribute{Optional: true, Description: "Only return applications in this category (case-insensitive)"},
+			"min_risk_score": schema.Int64Attribute{Optional: true, Description: "Only return applications with at least this risk score"},
+			"max_risk_score": schema.Int64Attribute{Optional: true, Description: "Only return applications with at most this risk score"},
+			"applications": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching applications",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":         schema.StringAttribute{Computed: true, Description: "Application ID"},
+						"name":       schema.StringAttribute{Computed: true, Description: "Application name"},
+						"category":   schema.StringAttribute{Computed: true, Description: "Application category"},
+						"risk_score": schema.Int64Attribute{Computed: true, Description: "Weighted risk score"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *applicationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state applicationsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := d.client.resolveOrgID(state.OrgID)
+	applications, err := getAllPages[application](ctx, d.client, fmt.Sprintf(applicationsPath, orgID))
+	if err != nil {
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+
+	filter := strings.ToLower(state.NameFilter.ValueString())
+	state.Applications = []applicationDataModel{}
+	for _, a := range applications {
+		if filter != "" && !strings.Contains(strings.ToLower(a.Name), filter) {
+			continue
+		}
+		if !state.Category.IsNull() && !strings.EqualFold(a.Category, state.Category.ValueString()) {
+			continue
+		}
+		if !state.MinRiskScore.IsNull() && a.RiskScore < state.MinRiskScore.ValueInt64() {
+			continue
+		}
+		if !state.MaxRiskScore.IsNull() && a.RiskScore > state.MaxRiskScore.ValueInt64() {
+			continue
+		}
+		state.Applications = append(state.Applications, applicationDataModel{
+			ID:        types.StringValue(strconv.FormatInt(a.ID, 10)),
+			Name:      types.StringValue(a.Name),
+			Category:  types.StringValue(a.Category),
+			RiskScore: types.Int64Value(a.RiskScore),
+		})
+	}
+	state.ID = types.StringValue(orgID)
+	state.OrgID = types.StringValue(orgID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplicationsDataSource(t *testing.T) {
+	f := newFakeUmbrella(t)
+	for _, a := range []map[string]interface{}{
+		{"id": 801, "name": "Dropbox", "category": "Cloud Storage", "riskScore": 3},
+		{"id": 802, "name": "Box", "category": "Cloud Storage", "riskScore": 1},
+		{"id": 803, "name": "Facebook", "category": "Social Networking", "riskScore": 4},
+		{"id": 804, "name": "Dropsend", "category": "File Sharing", "riskScore": 5},
+	} {
+		f.seed(a, applicationsPath+"/%v", "1234", a["id"])
+	}
+	h := newTFHarness(t, f.api.client)
+
+	app := func(id, name, category string, risk int64) interface{} {
+		return map[string]interface{}{"id": id, "name": name, "category": category, "risk_score": risk}
+	}
+	dropbox := app("801", "Dropbox", "Cloud Storage", 3)
+	box := app("802", "Box", "Cloud Storage", 1)
+	facebook := app("803", "Facebook", "Social Networking", 4)
+	dropsend := app("804", "Dropsend", "File Sharing", 5)
+
+	for _, tc := range []struct {
+		attrs map[string]interface{}
+		want  []interface{}
+	}{
+		{map[string]interface{}{}, []interface{}{dropbox, box, facebook, dropsend}},
+		{map[string]interface{}{"name_filter": "DROP"}, []interface{}{dropbox, dropsend}},
+		{map[string]interface{}{"category": "cloud storage"}, []interface{}{dropbox, box}},
+		{map[string]interface{}{"min_risk_score": 4}, []interface{}{facebook, dropsend}},
+		{map[string]interface{}{"max_risk_score": 3}, []interface{}{dropbox, box}},
+		{map[string]interface{}{"name_filter": "drop", "min_risk_score": 2, "max_risk_score": 4}, []interface{}{dropbox}},
+		{map[string]interface{}{"category": "Cloud Storage", "min_risk_score": 5}, []interface{}{}},
+	} {
+		if got := h.readData("umbrella_applications", tc.attrs)["applications"]; !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: applications = %v, want %v", tc.attrs, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Data source: umbrella_categories
+// -----------------------------------------------------------------------------
+
+type categoriesDataSource struct{ client *apiClient }
+
+type categoriesModel struct {
+	ID         types.String        `tfsdk:"id"`
+	OrgID      types.String        `tfsdk:"org_id"`
+	Type       types.String        `tfsdk:"type"`
+	NameFilter types.String        `tfsdk:"name_filter"`
+	Categories []categoryDataModel `tfsdk:"categories"`
+}
+
+type categoryDataModel struct {
+	ID         types.Int64  `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Type       types.String `tfsdk:"type"`
+	Deprecated types.Bool   `tfsdk:"deprecated"`
+}
+
+// category is an entry of the Umbrella category catalogue.
+type category struct {
+	ID         int64  `json:"id"`
+	Label      string `json:"label"`
+	Type       string `json:"type"`
+	Deprecated bool   `json:"deprecated"`
+}
+
+func NewCategoriesDataSource() datasource.DataSource { return &categoriesDataSource{} }
+
+func (d *categoriesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "umbrella_categories"
+}
+
+func (d *categoriesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*apiClient)
+}
+
+func (d *categoriesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Umbrella security and content category catalogue",
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true, Description: "Organisation ID"},
+			"org_id":      dataSourceOrgIDAttribute(),
+			"type":        schema.StringAttribute{Optional: true, Description: "Only return categories of this type (e.g. security, content)"},
+			"name_filter": schema.StringAttribute{Optional: true, Description: "Only return categories whose name contains this string (case-insensitive)"},
+			"categories": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching categories",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":         schema.Int64Attribute{Computed: true, Description: "Category ID"},
+						"name":       schema.StringAttribute{Computed: true, Description: "Category name"},
+						"type":       schema.StringAttribute{Computed: true, Description: "Category type"},
+						"deprecated": schema.BoolAttribute{Computed: true, Description: "Whether Cisco has retired the category"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *categoriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state categoriesModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := d.client.resolveOrgID(state.OrgID)
+	categories, err := d.client.listCategories(ctx, orgID)
+	if err != nil {
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+
+	filter := strings.ToLower(state.NameFilter.ValueString())
+	state.Categories = []categoryDataModel{}
+	for _, c := range categories {
+		if !state.Type.IsNull() && !strings.EqualFold(c.Type, state.Type.ValueString()) {
+			continue
+		}
+		if filter != "" && !strings.Contains(strings.ToLower(c.Label), filter) {
+			continue
+		}
+		state.Categories = append(state.Categories, categoryDataModel{
+			ID:         types.Int64Value(c.ID),
+			Name:       types.StringValue(c.Label),
+			Type:       types.StringValue(c.Type),
+			Deprecated: types.BoolValue(c.Deprecated),
+		})
+	}
+	state.ID = types.StringValue(orgID)
+	state.OrgID = types.StringValue(orgID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// listCategories returns the full category catalogue of an organisation.
+func (c *apiClient) listCategories(ctx context.Context, orgID string) ([]category, error) {
+	var categories []category
+	if err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf(categoriesPath, orgID), nil, &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
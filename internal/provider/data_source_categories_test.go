@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCategoriesDataSource(t *testing.T) {
+	f := newFakeUmbrella(t)
+	h := newTFHarness(t, f.api.client)
+
+	all := []interface{}{}
+	for _, c := range fakeCategories {
+		all = append(all, map[string]interface{}{"id": int64(c["id"].(int)), "name": c["label"], "type": c["type"], "deprecated": c["deprecated"]})
+	}
+	category := func(id int64, name, typ string, deprecated bool) interface{} {
+		return map[string]interface{}{"id": id, "name": name, "type": typ, "deprecated": deprecated}
+	}
+	tunneling := category(104, "DNS Tunneling VPN", "security", false)
+	dynamicDNS := category(107, "Dynamic DNS", "security", false)
+	gambling := category(200, "Gambling", "content", false)
+	adult := category(201, "Adult", "content", false)
+	social := category(202, "Social Networking", "content", false)
+	p2p := category(203, "Peer File Transfer", "content", true)
+
+	for _, tc := range []struct {
+		attrs map[string]interface{}
+		want  []interface{}
+	}{
+		{map[string]interface{}{}, all},
+		{map[string]interface{}{"type": "Content"}, []interface{}{gambling, adult, social, p2p}},
+		{map[string]interface{}{"name_filter": "DNS"}, []interface{}{tunneling, dynamicDNS}},
+		{map[string]interface{}{"type": "content", "name_filter": "ing"}, []interface{}{gambling, social}},
+		{map[string]interface{}{"type": "security", "name_filter": "gambling"}, []interface{}{}},
+	} {
+		if got := h.readData("umbrella_categories", tc.attrs)["categories"]; !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: categories = %v, want %v", tc.attrs, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Data source: umbrella_child_organizations
+// -----------------------------------------------------------------------------
+
+type childOrganizationsDataSource struct{ client *apiClient }
+
+type childOrganizationsModel struct {
+	ID            types.String             `tfsdk:"id"`
+	NameFilter    types.String             `tfsdk:"name_filter"`
+	Organizations []childOrganizationModel `tfsdk:"organizations"`
+}
+
+type childOrganizationModel struct {
+	OrgID types.String `tfsdk:"org_id"`
+	Name  types.String `tfsdk:"name"`
+	Seats types.Int64  `tfsdk:"seats"`
+}
+
+func NewChildOrganizationsDataSource() datasource.DataSource { return &childOrganizationsDataSource{} }
+
+func (d *childOrganizationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "umbrella_child_organizations"
+}
+
+func (d *childOrganizationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*apiClient)
+}
+
+func (d *childOrganizationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Child organisations managed by the provider's MSP / Multi-Org credentials",
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true, Description: "Parent organisation ID"},
+			"name_filter": schema.StringAttribute{Optional: true, Description: "Only return organisations whose name contains this string (case-insensitive)"},
+			"organizations": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Child organisations; use org_id as the org_id override on resources",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"org_id": schema.StringAttribute{Computed: true, Description: "Child organisation ID"},
+						"name":   schema.StringAttribute{Computed: true, Description: "Child organisation name"},
+						"seats":  schema.Int64Attribute{Computed: true, Description: "Licensed seats"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *childOrganizationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state childOrganizationsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var customers []struct {
+		CustomerID   int64  `json:"customerId"`
+		CustomerName string `json:"customerName"`
+		Seats        int64  `json:"seats"`
+	}
+	if err := d.client.doJSON(ctx, http.MethodGet, childOrgsPath, nil, &customers); err != nil {
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+
+	filter := strings.ToLower(state.NameFilter.ValueString())
+	state.Organizations = []childOrganizationModel{}
+	for _, c := range customers {
+		if filter != "" && !strings.Contains(strings.ToLower(c.CustomerName), filter) {
+			continue
+		}
+		state.Organizations = append(state.Organizations, childOrganizationModel{
+			OrgID: types.StringValue(fmt.Sprintf("%d", c.CustomerID)),
+			Name:  types.StringValue(c.CustomerName),
+			Seats: types.Int64Value(c.Seats),
+		})
+	}
+	state.ID = types.StringValue(d.client.orgID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChildOrganizationsDataSource(t *testing.T) {
+	f := newFakeUmbrella(t)
+	f.customers = []map[string]interface{}{
+		{"customerId": 5678, "customerName": "Acme Retail", "seats": 250},
+		{"customerId": 5679, "customerName": "Globex", "seats": 40},
+		{"customerId": 5680, "customerName": "ACME Logistics", "seats": 75},
+	}
+	h := newTFHarness(t, f.api.client)
+
+	for filter, want := range map[string][]interface{}{
+		"": {
+			map[string]interface{}{"org_id": "5678", "name": "Acme Retail", "seats": int64(250)},
+			map[string]interface{}{"org_id": "5679", "name": "Globex", "seats": int64(40)},
+			map[string]interface{}{"org_id": "5680", "name": "ACME Logistics", "seats": int64(75)},
+		},
+		"acme": {
+			map[string]interface{}{"org_id": "5678", "name": "Acme Retail", "seats": int64(250)},
+			map[string]interface{}{"org_id": "5680", "name": "ACME Logistics", "seats": int64(75)},
+		},
+		"initech": {},
+	} {
+		attrs := map[string]interface{}{}
+		if filter != "" {
+			attrs["name_filter"] = filter
+		}
+		state := h.readData("umbrella_child_organizations", attrs)
+		if got := state["organizations"]; !reflect.DeepEqual(got, want) {
+			t.Errorf("name_filter %q: organizations = %v, want %v", filter, got, want)
+		}
+		if state["id"] != "1234" {
+			t.Errorf("name_filter %q: id = %v, want the parent org 1234", filter, state["id"])
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Data source: umbrella_dns_policies
+// -----------------------------------------------------------------------------
+
+type dnsPoliciesDataSource struct{ client *apiClient }
+
+type dnsPoliciesModel struct {
+	ID         types.String         `tfsdk:"id"`
+	OrgID      types.String         `tfsdk:"org_id"`
+	NameFilter types.String         `tfsdk:"name_filter"`
+	Policies   []dnsPolicyDataModel `tfsdk:"policies"`
+}
+
+type dnsPolicyDataModel struct {
+	ID                       types.String `tfsdk:"id"`
+	Name                     types.String `tfsdk:"name"`
+	Priority                 types.Int64  `tfsdk:"priority"`
+	IsDefault                types.Bool   `tfsdk:"is_default"`
+	SecuritySettingID        types.String `tfsdk:"security_setting_id"`
+	ContentCategorySettingID types.String `tfsdk:"content_category_setting_id"`
+}
+
+func NewDNSPoliciesDataSource() datasource.DataSource { return &dnsPoliciesDataSource{} }
+
+func (d *dnsPoliciesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "umbrella_dns_policies"
+}
+
+func (d *dnsPoliciesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*apiClient)
+}
+
+func (d *dnsPoliciesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "DNS policies in an Umbrella organisation, in priority order",
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true, Description: "Organisation ID"},
+			"org_id":      dataSourceOrgIDAttribute(),
+			"name_filter": schema.StringAttribute{Optional: true, Description: "Only return policies whose name contains this string (case-insensitive)"},
+			"policies": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching DNS policies",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":                          schema.StringAttribute{Computed: true, Description: "DNS policy ID"},
+						"name":                        schema.StringAttribute{Computed: true, Description: "DNS policy name"},
+						"priority":                    schema.Int64Attribute{Computed: true, Description: "Evaluation order (lower numbers = higher priority)"},
+						"is_default":                  schema.BoolAttribute{Computed: true, Description: "Whether this is the default DNS policy"},
+						"security_setting_id":         schema.StringAttribute{Computed: true, Description: "Security setting ID applied by the policy"},
+						"content_category_setting_id": schema.StringAttribute{Computed: true, Description: "Content category setting ID applied by the policy"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *dnsPoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state dnsPoliciesModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := d.client.resolveOrgID(state.OrgID)
+	policies, err := getAllPages[dnsPolicy](ctx, d.client, fmt.Sprintf(dnsPolicyPath, orgID))
+	if err != nil {
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+
+	filter := strings.ToLower(state.NameFilter.ValueString())
+	state.Policies = []dnsPolicyDataModel{}
+	for _, p := range policies {
+		if filter != "" && !strings.Contains(strings.ToLower(p.Name), filter) {
+			continue
+		}
+		state.Policies = append(state.Policies, dnsPolicyDataModel{
+			ID:                       types.StringValue(p.ID),
+			Name:                     types.StringValue(p.Name),
+			Priority:                 types.Int64Value(p.Priority),
+			IsDefault:                types.BoolValue(p.IsDefault),
+			SecuritySettingID:        types.StringValue(p.SecuritySettingID),
+			ContentCategorySettingID: types.StringValue(p.ContentCategorySettingID),
+		})
+	}
+	state.ID = types.StringValue(orgID)
+	state.OrgID = types.StringValue(orgID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
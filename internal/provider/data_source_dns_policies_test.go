@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDNSPoliciesDataSource(t *testing.T) {
+	f := newFakeUmbrella(t)
+	for _, p := range []map[string]interface{}{
+		{"id": "701", "name": "Office", "priority": 1, "isDefault": false, "securitySettingId": "55", "contentCategorySettingId": "66"},
+		{"id": "702", "name": "Guest WiFi", "priority": 2, "isDefault": false, "securitySettingId": "56"},
+		{"id": "703", "name": "Default Policy", "priority": 99, "isDefault": true},
+	} {
+		f.seed(p, dnsPolicyPath+"/%s", "1234", p["id"])
+	}
+	h := newTFHarness(t, f.api.client)
+
+	office := map[string]interface{}{
+		"id": "701", "name": "Office", "priority": int64(1), "is_default": false,
+		"security_setting_id": "55", "content_category_setting_id": "66",
+	}
+	guest := map[string]interface{}{
+		"id": "702", "name": "Guest WiFi", "priority": int64(2), "is_default": false,
+		"security_setting_id": "56", "content_category_setting_id": "",
+	}
+	def := map[string]interface{}{
+		"id": "703", "name": "Default Policy", "priority": int64(99), "is_default": true,
+		"security_setting_id": "", "content_category_setting_id": "",
+	}
+	for filter, want := range map[string][]interface{}{
+		"":        {office, guest, def},
+		"OFFICE":  {office},
+		"branch":  {},
+		"default": {def},
+	} {
+		attrs := map[string]interface{}{}
+		if filter != "" {
+			attrs["name_filter"] = filter
+		}
+		if got := h.readData("umbrella_dns_policies", attrs)["policies"]; !reflect.DeepEqual(got, want) {
+			t.Errorf("name_filter %q: policies = %v, want %v", filter, got, want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Data source: umbrella_networks
+// -----------------------------------------------------------------------------
+
+type networksDataSource struct{ client *apiClient }
+
+type networksModel struct {
+	ID         types.String       `tfsdk:"id"`
+	OrgID      types.String       `tfsdk:"org_id"`
+	NameFilter types.String       `tfsdk:"name_filter"`
+	Networks   []networkDataModel `tfsdk:"networks"`
+}
+
+type networkDataModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	IPAddress    types.String `tfsdk:"ip_address"`
+	PrefixLength types.Int64  `tfsdk:"prefix_length"`
+	IsDynamic    types.Bool   `tfsdk:"is_dynamic"`
+	Status       types.String `tfsdk:"status"`
+	IsVerified   types.Bool   `tfsdk:"is_verified"`
+}
+
+func NewNetworksDataSource() datasource.DataSource { return &networksDataSource{} }
+
+func (d *networksDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "umbrella_networks"
+}
+
+func (d *networksDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*apiClient)
+}
+
+func (d *networksDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Network identities in an Umbrella organisation",
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true, Description: "Organisation ID"},
+			"org_id":      dataSourceOrgIDAttribute(),
+			"name_filter": schema.StringAttribute{Optional: true, Description: "Only return networks whose name contains this string (case-insensitive)"},
+			"networks": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching networks",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":            schema.StringAttribute{Computed: true, Description: "Network origin ID"},
+						"name":          schema.StringAttribute{Computed: true, Description: "Network name"},
+						"ip_address":    schema.StringAttribute{Computed: true, Description: "Public egress IP address"},
+						"prefix_length": schema.Int64Attribute{Computed: true, Description: "Prefix length of the egress range"},
+						"is_dynamic":    schema.BoolAttribute{Computed: true, Description: "Whether the network's IP is dynamic"},
+						"status":        schema.StringAttribute{Computed: true, Description: "Network status"},
+						"is_verified":   schema.BoolAttribute{Computed: true, Description: "Whether Umbrella has verified ownership of the network"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *networksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state networksModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := d.client.resolveOrgID(state.OrgID)
+	networks, err := getAllPages[network](ctx, d.client, fmt.Sprintf(networksPath, orgID))
+	if err != nil {
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+
+	filter := strings.ToLower(state.NameFilter.ValueString())
+	state.Networks = []networkDataModel{}
+	for _, n := range networks {
+		if filter != "" && !strings.Contains(strings.ToLower(n.Name), filter) {
+			continue
+		}
+		state.Networks = append(state.Networks, networkDataModel{
+			ID:           types.StringValue(strconv.FormatInt(n.OriginID, 10)),
+			Name:         types.StringValue(n.Name),
+			IPAddress:    types.StringValue(n.IPAddress),
+			PrefixLength: types.Int64Value(n.PrefixLength),
+			IsDynamic:    types.BoolValue(n.IsDynamic),
+			Status:       types.StringValue(n.Status),
+			IsVerified:   types.BoolValue(n.IsVerified),
+		})
+	}
+	state.ID = types.StringValue(orgID)
+	state.OrgID = types.StringValue(orgID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
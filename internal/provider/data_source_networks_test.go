@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNetworksDataSource(t *testing.T) {
+	f := newFakeUmbrella(t)
+	for _, n := range []map[string]interface{}{
+		{"originId": 601, "name": "HQ egress", "ipAddress": "198.51.100.0", "prefixLength": 29, "isDynamic": false, "status": "OPEN", "isVerified": true},
+		{"originId": 602, "name": "Branch egress", "ipAddress": "203.0.113.7", "prefixLength": 32, "isDynamic": true, "status": "OPEN", "isVerified": false},
+	} {
+		f.seed(n, networksPath+"/%v", "1234", n["originId"])
+	}
+	h := newTFHarness(t, f.api.client)
+
+	hq := map[string]interface{}{
+		"id": "601", "name": "HQ egress", "ip_address": "198.51.100.0", "prefix_length": int64(29),
+		"is_dynamic": false, "status": "OPEN", "is_verified": true,
+	}
+	branch := map[string]interface{}{
+		"id": "602", "name": "Branch egress", "ip_address": "203.0.113.7", "prefix_length": int64(32),
+		"is_dynamic": true, "status": "OPEN", "is_verified": false,
+	}
+	for filter, want := range map[string][]interface{}{
+		"":       {hq, branch},
+		"BRANCH": {branch},
+		"guest":  {},
+	} {
+		attrs := map[string]interface{}{}
+		if filter != "" {
+			attrs["name_filter"] = filter
+		}
+		if got := h.readData("umbrella_networks", attrs)["networks"]; !reflect.DeepEqual(got, want) {
+			t.Errorf("name_filter %q: networks = %v, want %v", filter, got, want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Data source: umbrella_roaming_computers
+// -----------------------------------------------------------------------------
+
+type roamingComputersDataSource struct{ client *apiClient }
+
+type roamingComputersModel struct {
+	ID             types.String               `tfsdk:"id"`
+	OrgID          types.String               `tfsdk:"org_id"`
+	NameFilter     types.String               `tfsdk:"name_filter"`
+	OS             types.String               `tfsdk:"os"`
+	Status         types.String               `tfsdk:"status"`
+	LastSyncWithin types.String               `tfsdk:"last_sync_within"`
+	Tag            types.String               `tfsdk:"tag"`
+	Computers      []roamingComputerDataModel `tfsdk:"computers"`
+}
+
+type roamingComputerDataModel struct {
+	ID       types.String `tfsdk:"id"`
+	DeviceID types.String `tfsdk:"device_id"`
+	Name     types.String `tfsdk:"name"`
+	OS       types.String `tfsdk:"os"`
+	Status   types.String `tfsdk:"status"`
+	LastSync types.String `tfsdk:"last_sync"`
+	Tags     types.Set    `tfsdk:"tags"`
+}
+
+// roamingComputer is the deployments API representation of an enrolled
+// roaming client.
+type roamingComputer struct {
+	OriginID      int64    `json:"originId"`
+	DeviceID      string   `json:"deviceId"`
+	Name          string   `json:"name"`
+	OSVersionName string   `json:"osVersionName"`
+	Status        string   `json:"status"`
+	LastSync      string   `json:"lastSync"`
+	Tags          []string `json:"tags"`
+}
+
+func NewRoamingComputersDataSource() datasource.DataSource { return &roamingComputersDataSource{} }
+
+func (d *roamingComputersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "umbrella_roaming_computers"
+}
+
+func (d *roamingComputersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*apiClient)
+}
+
+func (d *roamingComputersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Roaming computers enrolled in an Umbrella organisation",
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true, Description: "Organisation ID"},
+			"org_id":      dataSourceOrgIDAttribute(),
+			"name_filter": schema.StringAttribute{Optional: true, Description: "Only return computers whose name contains this string (case-insensitive)"},
+			"os":          schema.StringAttribute{Optional: true, Description: "Only return computers whose OS name contains this string (case-insensitive), e.g. \"Windows\""},
+			"status":      schema.StringAttribute{Optional: true, Description: "Only return computers with this status (case-insensitive), e.g. \"Protected\""},
+			"last_sync_within": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return computers that synced within this duration, e.g. \"168h\"",
+				Validators:  []validator.String{durationValidator{}},
+			},
+			"tag": schema.StringAttribute{Optional: true, Description: "Only return computers carrying this tag"},
+			"computers": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching roaming computers",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":        schema.StringAttribute{Computed: true, Description: "Origin ID, usable in ruleset identities.roaming_computers"},
+						"device_id": schema.StringAttribute{Computed: true, Description: "Device ID"},
+						"name":      schema.StringAttribute{Computed: true, Description: "Computer name"},
+						"os":        schema.StringAttribute{Computed: true, Description: "Operating system name"},
+						"status":    schema.StringAttribute{Computed: true, Description: "Protection status"},
+						"last_sync": schema.StringAttribute{Computed: true, Description: "Last sync timestamp in ISO 8601 format"},
+						"tags":      schema.SetAttribute{Computed: true, ElementType: types.StringType, Description: "Tags assigned to the computer"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *roamingComputersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state roamingComputersModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var syncedAfter time.Time
+	if !state.LastSyncWithin.IsNull() {
+		within, err := time.ParseDuration(state.LastSyncWithin.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("last_sync_within"), "Invalid duration", err.Error())
+			return
+		}
+		syncedAfter = time.Now().Add(-within)
+	}
+
+	orgID := d.client.resolveOrgID(state.OrgID)
+	computers, err := getAllPages[roamingComputer](ctx, d.client, fmt.Sprintf(roamingComputersPath, orgID))
+	if err != nil {
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+
+	nameFilter := strings.ToLower(state.NameFilter.ValueString())
+	osFilter := strings.ToLower(state.OS.ValueString())
+	state.Computers = []roamingComputerDataModel{}
+	for _, c := range computers {
+		if nameFilter != "" && !strings.Contains(strings.ToLower(c.Name), nameFilter) {
+			continue
+		}
+		if osFilter != "" && !strings.Contains(strings.ToLower(c.OSVersionName), osFilter) {
+			continue
+		}
+		if !state.Status.IsNull() && !strings.EqualFold(c.Status, state.Status.ValueString()) {
+			continue
+		}
+		if !syncedAfter.IsZero() {
+			lastSync, err := time.Parse(time.RFC3339, c.LastSync)
+			if err != nil || lastSync.Before(syncedAfter) {
+				continue
+			}
+		}
+		if !state.Tag.IsNull() && !containsString(c.Tags, state.Tag.ValueString()) {
+			continue
+		}
+		tags, _ := types.SetValue(types.StringType, stringSliceToAttrValues(c.Tags))
+		state.Computers = append(state.Computers, roamingComputerDataModel{
+			ID:       types.StringValue(strconv.FormatInt(c.OriginID, 10)),
+			DeviceID: types.StringValue(c.DeviceID),
+			Name:     types.StringValue(c.Name),
+			OS:       types.StringValue(c.OSVersionName),
+			Status:   types.StringValue(c.Status),
+			LastSync: types.StringValue(c.LastSync),
+			Tags:     tags,
+		})
+	}
+	state.ID = types.StringValue(orgID)
+	state.OrgID = types.StringValue(orgID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
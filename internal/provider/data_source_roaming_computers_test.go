@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRoamingComputersDataSource(t *testing.T) {
+	f := newFakeUmbrella(t)
+	synced := func(ago time.Duration) string { return time.Now().Add(-ago).UTC().Format(time.RFC3339) }
+	for _, c := range []map[string]interface{}{
+		{"deviceId": "dev-a", "originId": 301, "name": "FIN-LT-01", "osVersionName": "Windows 11", "status": "Protected", "lastSync": synced(time.Hour), "tags": []string{"finance", "laptops"}},
+		{"deviceId": "dev-b", "originId": 302, "name": "fin-mb-02", "osVersionName": "macOS 14", "status": "Unprotected", "lastSync": synced(48 * time.Hour), "tags": []string{"finance"}},
+		{"deviceId": "dev-c", "originId": 303, "name": "ENG-LT-03", "osVersionName": "Windows 10", "status": "protected", "lastSync": synced(30 * 24 * time.Hour), "tags": []string{}},
+	} {
+		f.seed(c, roamingComputersPath+"/%s", "1234", c["deviceId"])
+	}
+	h := newTFHarness(t, f.api.client)
+
+	for _, tc := range []struct {
+		attrs map[string]interface{}
+		want  []string
+	}{
+		{map[string]interface{}{}, []string{"dev-a", "dev-b", "dev-c"}},
+		{map[string]interface{}{"name_filter": "fin"}, []string{"dev-a", "dev-b"}},
+		{map[string]interface{}{"os": "windows"}, []string{"dev-a", "dev-c"}},
+		{map[string]interface{}{"status": "PROTECTED"}, []string{"dev-a", "dev-c"}},
+		{map[string]interface{}{"last_sync_within": "72h"}, []string{"dev-a", "dev-b"}},
+		{map[string]interface{}{"tag": "finance"}, []string{"dev-a", "dev-b"}},
+		{map[string]interface{}{"tag": "finance", "os": "Windows", "last_sync_within": "24h"}, []string{"dev-a"}},
+		{map[string]interface{}{"tag": "Finance"}, []string{}},
+	} {
+		got := []string{}
+		for _, c := range h.readData("umbrella_roaming_computers", tc.attrs)["computers"].([]interface{}) {
+			got = append(got, c.(map[string]interface{})["device_id"].(string))
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: computers = %v, want %v", tc.attrs, got, tc.want)
+		}
+	}
+
+	computers := h.readData("umbrella_roaming_computers", map[string]interface{}{"name_filter": "LT-01"})["computers"].([]interface{})
+	want := map[string]interface{}{"id": "301", "device_id": "dev-a", "name": "FIN-LT-01", "os": "Windows 11", "status": "Protected"}
+	for k, v := range want {
+		if got := computers[0].(map[string]interface{})[k]; got != v {
+			t.Errorf("FIN-LT-01: %s = %v, want %v", k, got, v)
+		}
+	}
+
+	if msg := h.validateData("umbrella_roaming_computers", map[string]interface{}{"last_sync_within": "a week"}); msg == "" {
+		t.Error(`last_sync_within "a week" was accepted`)
+	}
+}
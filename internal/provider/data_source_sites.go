@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Data source: umbrella_sites
+// -----------------------------------------------------------------------------
+
+type sitesDataSource struct{ client *apiClient }
+
+type sitesModel struct {
+	ID         types.String    `tfsdk:"id"`
+	OrgID      types.String    `tfsdk:"org_id"`
+	NameFilter types.String    `tfsdk:"name_filter"`
+	Sites      []siteDataModel `tfsdk:"sites"`
+}
+
+type siteDataModel struct {
+	ID        types.String `tfsdk:"id"`
+	OriginID  types.Int64  `tfsdk:"origin_id"`
+	Name      types.String `tfsdk:"name"`
+	IsDefault types.Bool   `tfsdk:"is_default"`
+	Type      types.String `tfsdk:"type"`
+}
+
+func NewSitesDataSource() datasource.DataSource { return &sitesDataSource{} }
+
+func (d *sitesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "umbrella_sites"
+}
+
+func (d *sitesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*apiClient)
+}
+
+func (d *sitesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Sites in an Umbrella organisation",
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true, Description: "Organisation ID"},
+			"org_id":      dataSourceOrgIDAttribute(),
+			"name_filter": schema.StringAttribute{Optional: true, Description: "Only return sites whose name contains this string (case-insensitive)"},
+			"sites": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching sites",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":         schema.StringAttribute{Computed: true, Description: "Site ID"},
+						"origin_id":  schema.Int64Attribute{Computed: true, Description: "Origin ID of the site, as used by umbrella_tunnel.site_origin_id"},
+						"name":       schema.StringAttribute{Computed: true, Description: "Site name"},
+						"is_default": schema.BoolAttribute{Computed: true, Description: "Whether this is the organisation's default site"},
+						"type":       schema.StringAttribute{Computed: true, Description: "Site type"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *sitesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state sitesModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := d.client.resolveOrgID(state.OrgID)
+	sites, err := getAllPages[site](ctx, d.client, fmt.Sprintf(sitesPath, orgID))
+	if err != nil {
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+
+	filter := strings.ToLower(state.NameFilter.ValueString())
+	state.Sites = []siteDataModel{}
+	for _, s := range sites {
+		if filter != "" && !strings.Contains(strings.ToLower(s.Name), filter) {
+			continue
+		}
+		state.Sites = append(state.Sites, siteDataModel{
+			ID:        types.StringValue(strconv.FormatInt(s.SiteID, 10)),
+			OriginID:  types.Int64Value(s.OriginID),
+			Name:      types.StringValue(s.Name),
+			IsDefault: types.BoolValue(s.IsDefault),
+			Type:      types.StringValue(s.Type),
+		})
+	}
+	state.ID = types.StringValue(orgID)
+	state.OrgID = types.StringValue(orgID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSitesDataSource(t *testing.T) {
+	f := newFakeUmbrella(t)
+	for _, s := range []map[string]interface{}{
+		{"siteId": 1, "originId": 501, "name": "Default Site", "isDefault": true, "type": "site"},
+		{"siteId": 2, "originId": 502, "name": "Branch London", "isDefault": false, "type": "site"},
+		{"siteId": 3, "originId": 503, "name": "branch Paris", "isDefault": false, "type": "site"},
+	} {
+		f.seed(s, sitesPath+"/%v", "1234", s["siteId"])
+	}
+	h := newTFHarness(t, f.api.client)
+
+	london := map[string]interface{}{"id": "2", "origin_id": int64(502), "name": "Branch London", "is_default": false, "type": "site"}
+	paris := map[string]interface{}{"id": "3", "origin_id": int64(503), "name": "branch Paris", "is_default": false, "type": "site"}
+	for filter, want := range map[string][]interface{}{
+		"": {
+			map[string]interface{}{"id": "1", "origin_id": int64(501), "name": "Default Site", "is_default": true, "type": "site"},
+			london,
+			paris,
+		},
+		"BRANCH": {london, paris},
+		"tokyo":  {},
+	} {
+		attrs := map[string]interface{}{}
+		if filter != "" {
+			attrs["name_filter"] = filter
+		}
+		state := h.readData("umbrella_sites", attrs)
+		if got := state["sites"]; !reflect.DeepEqual(got, want) {
+			t.Errorf("name_filter %q: sites = %v, want %v", filter, got, want)
+		}
+		if state["org_id"] != "1234" {
+			t.Errorf("name_filter %q: org_id = %v, want the provider's 1234", filter, state["org_id"])
+		}
+	}
+
+	if msg := h.validateData("umbrella_sites", map[string]interface{}{"org_id": ""}); msg == "" {
+		t.Error(`org_id = "": expected an error`)
+	}
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Data source: umbrella_virtual_appliances
+// -----------------------------------------------------------------------------
+
+type virtualAppliancesDataSource struct{ client *apiClient }
+
+type virtualAppliancesModel struct {
+	ID         types.String                `tfsdk:"id"`
+	OrgID      types.String                `tfsdk:"org_id"`
+	NameFilter types.String                `tfsdk:"name_filter"`
+	SiteID     types.Int64                 `tfsdk:"site_id"`
+	Health     types.String                `tfsdk:"health"`
+	Appliances []virtualApplianceDataModel `tfsdk:"appliances"`
+}
+
+type virtualApplianceDataModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	SiteID      types.Int64  `tfsdk:"site_id"`
+	Health      types.String `tfsdk:"health"`
+	Version     types.String `tfsdk:"version"`
+	InternalIP  types.String `tfsdk:"internal_ip"`
+	ExternalIP  types.String `tfsdk:"external_ip"`
+	LastSync    types.String `tfsdk:"last_sync"`
+	UpstreamDNS types.List   `tfsdk:"upstream_dns"`
+}
+
+// virtualAppliance is the deployments API representation of a Virtual
+// Appliance. Only name, siteId and settings.upstreamDns can be changed; the
+// rest is reported by the appliance itself.
+type virtualAppliance struct {
+	OriginID int64                    `json:"originId"`
+	Name     string                   `json:"name"`
+	SiteID   int64                    `json:"siteId"`
+	Health   string                   `json:"health"`
+	Settings virtualApplianceSettings `json:"settings"`
+}
+
+type virtualApplianceSettings struct {
+	Version      string   `json:"version"`
+	InternalIP   string   `json:"internalIp"`
+	ExternalIP   string   `json:"externalIp"`
+	LastSyncTime string   `json:"lastSyncTime"`
+	UpstreamDNS  []string `json:"upstreamDns"`
+}
+
+func NewVirtualAppliancesDataSource() datasource.DataSource { return &virtualAppliancesDataSource{} }
+
+func (d *virtualAppliancesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "umbrella_virtual_appliances"
+}
+
+func (d *virtualAppliancesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*apiClient)
+}
+
+func (d *virtualAppliancesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Virtual Appliances deployed in an Umbrella organisation",
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true, Description: "Organisation ID"},
+			"org_id":      dataSourceOrgIDAttribute(),
+			"name_filter": schema.StringAttribute{Optional: true, Description: "Only return appliances whose name contains this string (case-insensitive)"},
+			"site_id":     schema.Int64Attribute{Optional: true, Description: "Only return appliances assigned to this site"},
+			"health":      schema.StringAttribute{Optional: true, Description: "Only return appliances with this health status (case-insensitive)"},
+			"appliances": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching Virtual Appliances",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":           schema.StringAttribute{Computed: true, Description: "Appliance origin ID"},
+						"name":         schema.StringAttribute{Computed: true, Description: "Appliance name"},
+						"site_id":      schema.Int64Attribute{Computed: true, Description: "Site the appliance is assigned to"},
+						"health":       schema.StringAttribute{Computed: true, Description: "Health status reported by the appliance"},
+						"version":      schema.StringAttribute{Computed: true, Description: "Appliance software version"},
+						"internal_ip":  schema.StringAttribute{Computed: true, Description: "Internal IP address"},
+						"external_ip":  schema.StringAttribute{Computed: true, Description: "Public egress IP address"},
+						"last_sync":    schema.StringAttribute{Computed: true, Description: "Last sync timestamp in ISO 8601 format"},
+						"upstream_dns": schema.ListAttribute{Computed: true, ElementType: types.StringType, Description: "Upstream (local) DNS servers, in order"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *virtualAppliancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state virtualAppliancesModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := d.client.resolveOrgID(state.OrgID)
+	appliances, err := getAllPages[virtualAppliance](ctx, d.client, fmt.Sprintf(virtualAppliancesPath, orgID))
+	if err != nil {
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+
+	filter := strings.ToLower(state.NameFilter.ValueString())
+	state.Appliances = []virtualApplianceDataModel{}
+	for _, va := range appliances {
+		if filter != "" && !strings.Contains(strings.ToLower(va.Name), filter) {
+			continue
+		}
+		if !state.SiteID.IsNull() && va.SiteID != state.SiteID.ValueInt64() {
+			continue
+		}
+		if !state.Health.IsNull() && !strings.EqualFold(va.Health, state.Health.ValueString()) {
+			continue
+		}
+		upstream, _ := types.ListValue(types.StringType, stringSliceToAttrValues(va.Settings.UpstreamDNS))
+		state.Appliances = append(state.Appliances, virtualApplianceDataModel{
+			ID:          types.StringValue(strconv.FormatInt(va.OriginID, 10)),
+			Name:        types.StringValue(va.Name),
+			SiteID:      types.Int64Value(va.SiteID),
+			Health:      types.StringValue(va.Health),
+			Version:     types.StringValue(va.Settings.Version),
+			InternalIP:  types.StringValue(va.Settings.InternalIP),
+			ExternalIP:  types.StringValue(va.Settings.ExternalIP),
+			LastSync:    types.StringValue(va.Settings.LastSyncTime),
+			UpstreamDNS: upstream,
+		})
+	}
+	state.ID = types.StringValue(orgID)
+	state.OrgID = types.StringValue(orgID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVirtualAppliancesDataSource(t *testing.T) {
+	f := newFakeUmbrella(t)
+	for _, va := range []map[string]interface{}{
+		{"originId": 501, "name": "branch-va-1", "siteId": 1, "health": "healthy", "settings": map[string]interface{}{
+			"version": "3.1", "internalIp": "10.10.0.5", "externalIp": "198.51.100.5", "lastSyncTime": "2024-01-01T00:00:00Z",
+			"upstreamDns": []string{"10.10.0.53", "10.10.1.53"},
+		}},
+		{"originId": 502, "name": "branch-va-2", "siteId": 2, "health": "Unhealthy", "settings": map[string]interface{}{"version": "3.0"}},
+		{"originId": 503, "name": "hq-va-1", "siteId": 1, "health": "Healthy", "settings": map[string]interface{}{"version": "3.1"}},
+	} {
+		f.seed(va, virtualAppliancesPath+"/%v", "1234", va["originId"])
+	}
+	h := newTFHarness(t, f.api.client)
+
+	for _, tc := range []struct {
+		attrs map[string]interface{}
+		want  []string
+	}{
+		{map[string]interface{}{}, []string{"501", "502", "503"}},
+		{map[string]interface{}{"name_filter": "BRANCH"}, []string{"501", "502"}},
+		{map[string]interface{}{"site_id": 1}, []string{"501", "503"}},
+		{map[string]interface{}{"health": "HEALTHY"}, []string{"501", "503"}},
+		{map[string]interface{}{"name_filter": "branch", "site_id": 1, "health": "healthy"}, []string{"501"}},
+		{map[string]interface{}{"site_id": 3}, []string{}},
+	} {
+		got := []string{}
+		for _, va := range h.readData("umbrella_virtual_appliances", tc.attrs)["appliances"].([]interface{}) {
+			got = append(got, va.(map[string]interface{})["id"].(string))
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: appliances = %v, want %v", tc.attrs, got, tc.want)
+		}
+	}
+
+	appliances := h.readData("umbrella_virtual_appliances", map[string]interface{}{"name_filter": "branch-va-1"})["appliances"]
+	want := []interface{}{map[string]interface{}{
+		"id": "501", "name": "branch-va-1", "site_id": int64(1), "health": "healthy", "version": "3.1",
+		"internal_ip": "10.10.0.5", "external_ip": "198.51.100.5", "last_sync": "2024-01-01T00:00:00Z",
+		"upstream_dns": []interface{}{"10.10.0.53", "10.10.1.53"},
+	}}
+	if !reflect.DeepEqual(appliances, want) {
+		t.Errorf("branch-va-1: appliances = %v, want %v", appliances, want)
+	}
+}
@@ -0,0 +1,481 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeCollection describes one Umbrella collection served by fakeUmbrella.
+type fakeCollection struct {
+	path     string   // one of the *Path constants
+	idKeys   []string // response fields set to the new object's ID
+	stringID bool     // IDs are JSON strings rather than numbers
+	defaults map[string]interface{}
+}
+
+var fakeCollections = []fakeCollection{
+	{path: destListPath, idKeys: []string{"id"}},
+	{path: tunnelPath, idKeys: []string{"id"}, stringID: true, defaults: map[string]interface{}{"status": "PENDING", "tunnelEndpoint": "203.0.113.1"}},
+	{path: rulesetPath, idKeys: []string{"id"}, stringID: true},
+	{path: rulePath, idKeys: []string{"id"}, stringID: true},
+	{path: sitesPath, idKeys: []string{"siteId", "originId"}, defaults: map[string]interface{}{"type": "site", "isDefault": false}},
+	{path: networksPath, idKeys: []string{"originId"}, defaults: map[string]interface{}{"isVerified": false}},
+	{path: internalNetworksPath, idKeys: []string{"originId"}},
+	{path: internalDomainsPath, idKeys: []string{"id"}},
+	{path: dnsPolicyPath, idKeys: []string{"id"}, stringID: true, defaults: map[string]interface{}{"priority": 10, "isDefault": false}},
+	{path: contentCategorySettingsPath, idKeys: []string{"id"}},
+	{path: applicationsPath, idKeys: []string{"id"}},
+	{path: securitySettingsPath, idKeys: []string{"id"}},
+	{path: selectiveDecryptionListsPath, idKeys: []string{"id"}},
+	{path: blockPagesPath, idKeys: []string{"id"}},
+	{path: warnPagesPath, idKeys: []string{"id"}},
+	{path: bypassCodesPath, idKeys: []string{"id"}, defaults: map[string]interface{}{"code": "BYPASS-1234", "expiresAt": "2030-01-01T00:00:00Z"}},
+	{path: bypassUsersPath, idKeys: []string{"id"}},
+	{path: tagsPath, idKeys: []string{"id"}},
+	{path: roamingComputersPath, idKeys: []string{"deviceId"}, stringID: true},
+	{path: virtualAppliancesPath, idKeys: []string{"originId"}},
+}
+
+// fakeCategories is the category catalogue served on categoriesPath.
+var fakeCategories = []map[string]interface{}{
+	{"id": 100, "label": "Malware", "type": "security", "deprecated": false},
+	{"id": 101, "label": "Phishing Attacks", "type": "security", "deprecated": false},
+	{"id": 102, "label": "Command and Control Callbacks", "type": "security", "deprecated": false},
+	{"id": 103, "label": "Newly Seen Domains", "type": "security", "deprecated": false},
+	{"id": 104, "label": "DNS Tunneling VPN", "type": "security", "deprecated": false},
+	{"id": 105, "label": "Cryptomining", "type": "security", "deprecated": false},
+	{"id": 106, "label": "Potentially Harmful", "type": "security", "deprecated": false},
+	{"id": 107, "label": "Dynamic DNS", "type": "security", "deprecated": false},
+	{"id": 108, "label": "Potentially Unwanted Applications", "type": "security", "deprecated": false},
+	{"id": 200, "label": "Gambling", "type": "content", "deprecated": false},
+	{"id": 201, "label": "Adult", "type": "content", "deprecated": false},
+	{"id": 202, "label": "Social Networking", "type": "content", "deprecated": false},
+	{"id": 203, "label": "Peer File Transfer", "type": "content", "deprecated": true},
+}
+
+var (
+	fakeDestinationsRoute = regexp.MustCompile(`^(/policies/v2/organizations/[^/]+/destinationlists/[^/]+)/destinations$`)
+	fakeSAMLRoute         = regexp.MustCompile(`^` + pathPattern(samlPath) + `$`)
+	fakeCategoriesRoute   = regexp.MustCompile(`^` + pathPattern(categoriesPath) + `$`)
+	fakeTagDevicesRoute   = regexp.MustCompile(`^(` + pathPattern(tagsPath) + `/[^/]+)/devices$`)
+	fakeComputerTagsRoute = regexp.MustCompile(`^(` + pathPattern(roamingComputersPath) + `/[^/]+)/tags$`)
+)
+
+// pathPattern turns a *Path constant into a regular expression, matching any
+// organisation or parent ID in place of each %s.
+func pathPattern(p string) string {
+	return strings.ReplaceAll(regexp.QuoteMeta(p), "%s", "[^/]+")
+}
+
+// fakeUmbrella is an in-memory Umbrella API. Objects are stored as the JSON
+// the provider sent, plus the server-assigned fields of their collection;
+// PUT and PATCH merge into the stored object.
+type fakeUmbrella struct {
+	t      *testing.T
+	api    *testAPI
+	mu     sync.Mutex
+	nextID int64
+	clock  int64
+
+	objects   map[string]map[string]interface{}   // item path -> object
+	members   map[string][]map[string]interface{} // parent path -> sub-collection
+	customers []map[string]interface{}            // child organisations
+	requests  []fakeRequest
+
+	// omit lists response fields left out of every object, as Umbrella does
+	// for some optional fields.
+	omit map[string]bool
+}
+
+// fakeRequest is one request received by fakeUmbrella.
+type fakeRequest struct {
+	method, path string
+	body         interface{}
+	userAgent    string
+}
+
+func newFakeUmbrella(t *testing.T, configure ...func(*httptest.Server)) *fakeUmbrella {
+	f := &fakeUmbrella{
+		t:       t,
+		nextID:  1000,
+		objects: map[string]map[string]interface{}{},
+		members: map[string][]map[string]interface{}{},
+		omit:    map[string]bool{},
+	}
+	f.api = newTestAPI(t, f.serveHTTP, configure...)
+	return f
+}
+
+// seed stores obj at the item path built from format and args, as if it had
+// been created outside Terraform.
+func (f *fakeUmbrella) seed(obj map[string]interface{}, format string, args ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[fmt.Sprintf(format, args...)] = obj
+}
+
+// object returns the stored object at the item path built from format and
+// args, failing the test when there is none.
+func (f *fakeUmbrella) object(format string, args ...interface{}) map[string]interface{} {
+	f.t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := fmt.Sprintf(format, args...)
+	obj, ok := f.objects[p]
+	if !ok {
+		f.t.Fatalf("fake Umbrella: no object at %s", p)
+	}
+	return obj
+}
+
+// received returns the requests with method whose path matches pattern.
+func (f *fakeUmbrella) received(method, pattern string) []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	re := regexp.MustCompile(pattern)
+	var out []fakeRequest
+	for _, req := range f.requests {
+		if req.method == method && re.MatchString(req.path) {
+			out = append(out, req)
+		}
+	}
+	return out
+}
+
+func (f *fakeUmbrella) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body interface{}
+	if r.ContentLength != 0 && r.Body != nil {
+		dec := json.NewDecoder(r.Body)
+		dec.UseNumber()
+		if err := dec.Decode(&body); err != nil {
+			http.Error(w, "bad JSON: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	p := r.URL.Path
+	f.requests = append(f.requests, fakeRequest{method: r.Method, path: p, body: body, userAgent: r.UserAgent()})
+
+	// Like Umbrella, only accept tokens scoped to the addressed organisation.
+	if m := orgPathPattern.FindStringSubmatch(p); m != nil {
+		want := m[1]
+		if want == f.api.client.orgID {
+			want = ""
+		}
+		if got := f.api.tokenOrg(r); got != want {
+			http.Error(w, fmt.Sprintf("token for organisation %q cannot access %s", got, p), http.StatusForbidden)
+			return
+		}
+	}
+
+	if p == childOrgsPath && r.Method == http.MethodGet {
+		f.writeJSON(w, http.StatusOK, f.customers)
+		return
+	}
+
+	if fakeSAMLRoute.MatchString(p) {
+		f.serveSAML(w, r, p, body)
+		return
+	}
+	if fakeCategoriesRoute.MatchString(p) && r.Method == http.MethodGet {
+		f.writeJSON(w, http.StatusOK, fakeCategories)
+		return
+	}
+	if m := fakeDestinationsRoute.FindStringSubmatch(p); m != nil {
+		f.serveMembers(w, r, m[1], body, "destination")
+		return
+	}
+	if m := fakeTagDevicesRoute.FindStringSubmatch(p); m != nil {
+		f.serveTagDevices(w, r, m[1], body)
+		return
+	}
+	if m := fakeComputerTagsRoute.FindStringSubmatch(p); m != nil && r.Method == http.MethodPut {
+		obj, ok := f.objects[m[1]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		obj["tags"] = body.(map[string]interface{})["tags"]
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	for _, c := range fakeCollections {
+		if regexp.MustCompile(`^` + pathPattern(c.path) + `$`).MatchString(p) {
+			f.serveCollection(w, r, c, p, body)
+			return
+		}
+		if regexp.MustCompile(`^` + pathPattern(c.path) + `/[^/]+$`).MatchString(p) {
+			f.serveItem(w, r, p, body)
+			return
+		}
+	}
+	f.t.Errorf("fake Umbrella: unexpected %s %s", r.Method, p)
+	http.NotFound(w, r)
+}
+
+func (f *fakeUmbrella) serveCollection(w http.ResponseWriter, r *http.Request, c fakeCollection, p string, body interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+		var paths []string
+		re := regexp.MustCompile(`^` + regexp.QuoteMeta(p) + `/[^/]+$`)
+		for item := range f.objects {
+			if re.MatchString(item) {
+				paths = append(paths, item)
+			}
+		}
+		sort.Strings(paths)
+		out := []interface{}{}
+		for _, item := range paths {
+			out = append(out, f.response(f.objects[item]))
+		}
+		f.writeList(w, r, out)
+	case http.MethodPost:
+		obj, ok := body.(map[string]interface{})
+		if !ok {
+			http.Error(w, "expected an object", http.StatusBadRequest)
+			return
+		}
+		f.nextID++
+		var id interface{} = f.nextID
+		if c.stringID {
+			id = strconv.FormatInt(f.nextID, 10)
+		}
+		for k, v := range c.defaults {
+			if _, set := obj[k]; !set {
+				obj[k] = v
+			}
+		}
+		for _, k := range c.idKeys {
+			obj[k] = id
+		}
+		obj["createdAt"] = f.timestamp()
+		f.touch(obj)
+		f.objects[fmt.Sprintf("%s/%v", p, id)] = obj
+		f.writeJSON(w, http.StatusOK, f.response(obj))
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeUmbrella) serveItem(w http.ResponseWriter, r *http.Request, p string, body interface{}) {
+	obj, ok := f.objects[p]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		f.writeJSON(w, http.StatusOK, f.response(obj))
+	case http.MethodPut, http.MethodPatch:
+		patch, ok := body.(map[string]interface{})
+		if !ok {
+			http.Error(w, "expected an object", http.StatusBadRequest)
+			return
+		}
+		mergeFakeObject(obj, patch)
+		f.touch(obj)
+		f.writeJSON(w, http.StatusOK, f.response(obj))
+	case http.MethodDelete:
+		delete(f.objects, p)
+		delete(f.members, p)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// serveMembers serves a destination list's destinations, which are added
+// with POST and removed with DELETE, both taking a list of entries.
+func (f *fakeUmbrella) serveMembers(w http.ResponseWriter, r *http.Request, parent string, body interface{}, key string) {
+	if _, ok := f.objects[parent]; !ok {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		out := []interface{}{}
+		for _, m := range f.members[parent] {
+			out = append(out, m)
+		}
+		f.writeList(w, r, out)
+		return
+	case http.MethodPost, http.MethodDelete:
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	entries, _ := body.([]interface{})
+	for _, e := range entries {
+		entry := e.(map[string]interface{})
+		kept := f.members[parent][:0]
+		for _, m := range f.members[parent] {
+			if m[key] != entry[key] {
+				kept = append(kept, m)
+			}
+		}
+		f.members[parent] = kept
+		if r.Method == http.MethodPost {
+			f.nextID++
+			entry["id"] = f.nextID
+			f.members[parent] = append(f.members[parent], entry)
+		}
+	}
+	f.writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ok"})
+}
+
+// serveTagDevices serves a tag's devices, changed by POSTing addOrigins or
+// removeOrigins.
+func (f *fakeUmbrella) serveTagDevices(w http.ResponseWriter, r *http.Request, parent string, body interface{}) {
+	if _, ok := f.objects[parent]; !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method == http.MethodGet {
+		out := []interface{}{}
+		for _, m := range f.members[parent] {
+			out = append(out, m)
+		}
+		f.writeList(w, r, out)
+		return
+	}
+	req, _ := body.(map[string]interface{})
+	for _, op := range []string{"addOrigins", "removeOrigins"} {
+		ids, _ := req[op].([]interface{})
+		for _, id := range ids {
+			kept := f.members[parent][:0]
+			for _, m := range f.members[parent] {
+				if m["originId"] != id {
+					kept = append(kept, m)
+				}
+			}
+			f.members[parent] = kept
+			if op == "addOrigins" {
+				f.members[parent] = append(f.members[parent], map[string]interface{}{"originId": id})
+			}
+		}
+	}
+	f.writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ok"})
+}
+
+// serveSAML serves the organisation's single SAML configuration.
+func (f *fakeUmbrella) serveSAML(w http.ResponseWriter, r *http.Request, p string, body interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+		obj, ok := f.objects[p]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		f.writeJSON(w, http.StatusOK, f.response(obj))
+	case http.MethodPut:
+		obj, _ := body.(map[string]interface{})
+		obj["enabled"] = true
+		f.objects[p] = obj
+		f.writeJSON(w, http.StatusOK, f.response(obj))
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// fakeDefaultPageSize is the page size used when a request has no limit.
+const fakeDefaultPageSize = 100
+
+// writeList writes one page of items, honouring the page and limit query
+// parameters used by getPages.
+func (f *fakeUmbrella) writeList(w http.ResponseWriter, r *http.Request, items []interface{}) {
+	page, limit := 1, fakeDefaultPageSize
+	if v, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && v > 0 {
+		page = v
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = v
+	}
+	start := (page - 1) * limit
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	f.writeJSON(w, http.StatusOK, items[start:end])
+}
+
+func (f *fakeUmbrella) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// response copies obj without the fields in f.omit.
+func (f *fakeUmbrella) response(obj map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, v := range obj {
+		if !f.omit[k] {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// timestamp returns a new, strictly increasing timestamp.
+func (f *fakeUmbrella) timestamp() string {
+	f.clock++
+	return fmt.Sprintf("2024-01-01T00:%02d:%02dZ", f.clock/60%60, f.clock%60)
+}
+
+func (f *fakeUmbrella) touch(obj map[string]interface{}) {
+	ts := f.timestamp()
+	obj["modifiedAt"] = ts
+	obj["updatedAt"] = ts
+}
+
+// mergeFakeObject merges patch into obj. Nested objects are merged
+// recursively and ruleset settings lists are merged by setting name, since
+// PATCH only sends the settings that changed.
+func mergeFakeObject(obj, patch map[string]interface{}) {
+	for k, v := range patch {
+		switch pv := v.(type) {
+		case map[string]interface{}:
+			if ov, ok := obj[k].(map[string]interface{}); ok {
+				mergeFakeObject(ov, pv)
+				continue
+			}
+		case []interface{}:
+			if ov, ok := obj[k].([]interface{}); ok && k == "settings" {
+				obj[k] = mergeFakeSettings(ov, pv)
+				continue
+			}
+		}
+		obj[k] = v
+	}
+}
+
+func mergeFakeSettings(have, changed []interface{}) []interface{} {
+	out := append([]interface{}{}, have...)
+	for _, c := range changed {
+		name := c.(map[string]interface{})["settingName"]
+		replaced := false
+		for i, h := range out {
+			if h.(map[string]interface{})["settingName"] == name {
+				out[i] = c
+				replaced = true
+			}
+		}
+		if !replaced {
+			out = append(out, c)
+		}
+	}
+	return out
+}
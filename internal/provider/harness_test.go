@@ -0,0 +1,586 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testProvider is the real provider, configured with a client for a local
+// API instead of credentials.
+type testProvider struct {
+	*umbrellaProvider
+	client *apiClient
+}
+
+func (p *testProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	resp.ResourceData = p.client
+	resp.DataSourceData = p.client
+}
+
+// tfHarness drives the provider over the plugin protocol the way Terraform
+// core does, so plan modifiers, defaults and the framework's consistency
+// checks all run.
+type tfHarness struct {
+	t           *testing.T
+	ctx         context.Context
+	server      tfprotov6.ProviderServer
+	schemas     map[string]*tfprotov6.Schema
+	dataSchemas map[string]*tfprotov6.Schema
+}
+
+func newTFHarness(t *testing.T, client *apiClient) *tfHarness {
+	t.Helper()
+	ctx := context.Background()
+	p := &testProvider{umbrellaProvider: &umbrellaProvider{version: "test"}, client: client}
+	server := providerserver.NewProtocol6(p)()
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &tfHarness{t: t, ctx: ctx, server: server, schemas: schemaResp.ResourceSchemas, dataSchemas: schemaResp.DataSourceSchemas}
+	h.check("get schema", schemaResp.Diagnostics)
+
+	providerType := schemaResp.Provider.ValueType()
+	cfg := h.dynamicValue(providerType, tftypes.NewValue(providerType, nullAttributes(providerType.(tftypes.Object))))
+	configResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: cfg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.check("configure provider", configResp.Diagnostics)
+	return h
+}
+
+func (h *tfHarness) check(step string, diags []*tfprotov6.Diagnostic) {
+	h.t.Helper()
+	if msg := diagnosticErrors(diags); msg != "" {
+		h.t.Fatalf("%s: %s", step, msg)
+	}
+}
+
+func diagnosticErrors(diags []*tfprotov6.Diagnostic) string {
+	var msgs []string
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			msgs = append(msgs, fmt.Sprintf("%s: %s (%v)", d.Summary, d.Detail, d.Attribute))
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (h *tfHarness) dynamicValue(typ tftypes.Type, v tftypes.Value) *tfprotov6.DynamicValue {
+	h.t.Helper()
+	dv, err := tfprotov6.NewDynamicValue(typ, v)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	return &dv
+}
+
+func (h *tfHarness) value(typ tftypes.Type, dv *tfprotov6.DynamicValue) tftypes.Value {
+	h.t.Helper()
+	if dv == nil {
+		return tftypes.NewValue(typ, nil)
+	}
+	v, err := dv.Unmarshal(typ)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	return v
+}
+
+// readData validates and reads data source typeName configured with attrs
+// (as accepted by tfValue) and returns its state as Go values (see goValue).
+func (h *tfHarness) readData(typeName string, attrs map[string]interface{}) map[string]interface{} {
+	h.t.Helper()
+	typ, config := h.dataConfig(typeName, attrs)
+	if msg := h.validateData(typeName, attrs); msg != "" {
+		h.t.Fatalf("%s: validate: %s", typeName, msg)
+	}
+	resp, err := h.server.ReadDataSource(h.ctx, &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config:   h.dynamicValue(typ, config),
+	})
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	h.check(typeName+": read", resp.Diagnostics)
+	return goValue(h.value(typ, resp.State)).(map[string]interface{})
+}
+
+// validateData returns the validation errors for data source typeName
+// configured with attrs, or "" when the configuration is valid.
+func (h *tfHarness) validateData(typeName string, attrs map[string]interface{}) string {
+	h.t.Helper()
+	typ, config := h.dataConfig(typeName, attrs)
+	resp, err := h.server.ValidateDataResourceConfig(h.ctx, &tfprotov6.ValidateDataResourceConfigRequest{
+		TypeName: typeName,
+		Config:   h.dynamicValue(typ, config),
+	})
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	return diagnosticErrors(resp.Diagnostics)
+}
+
+func (h *tfHarness) dataConfig(typeName string, attrs map[string]interface{}) (tftypes.Type, tftypes.Value) {
+	h.t.Helper()
+	s, ok := h.dataSchemas[typeName]
+	if !ok {
+		h.t.Fatalf("no data source %s", typeName)
+	}
+	typ := s.ValueType()
+	config, err := tfValue(typ, attrs)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	return typ, config
+}
+
+// tfResource is a single resource instance whose state is carried from one
+// step to the next.
+type tfResource struct {
+	h        *tfHarness
+	typeName string
+	schema   *tfprotov6.Schema
+	typ      tftypes.Object
+	state    tftypes.Value
+	private  []byte
+}
+
+func (h *tfHarness) resource(typeName string) *tfResource {
+	h.t.Helper()
+	s, ok := h.schemas[typeName]
+	if !ok {
+		h.t.Fatalf("no resource %s", typeName)
+	}
+	typ := s.ValueType().(tftypes.Object)
+	return &tfResource{h: h, typeName: typeName, schema: s, typ: typ, state: tftypes.NewValue(typ, nil)}
+}
+
+// config builds a configuration from attrs, leaving everything else null.
+// Values may be tftypes.Values or the Go values accepted by tfValue.
+func (r *tfResource) config(attrs map[string]interface{}) tftypes.Value {
+	r.h.t.Helper()
+	v, err := tfValue(r.typ, attrs)
+	if err != nil {
+		r.h.t.Fatal(err)
+	}
+	return v
+}
+
+// validate runs ValidateResourceConfig and, when that passes, plans the
+// creation of config, returning the error diagnostics of either.
+func (r *tfResource) validate(config tftypes.Value) string {
+	r.h.t.Helper()
+	resp, err := r.h.server.ValidateResourceConfig(r.h.ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: r.typeName,
+		Config:   r.h.dynamicValue(r.typ, config),
+	})
+	if err != nil {
+		r.h.t.Fatal(err)
+	}
+	if msg := diagnosticErrors(resp.Diagnostics); msg != "" {
+		return msg
+	}
+	null := tftypes.NewValue(r.typ, nil)
+	_, _, msg := r.plan(null, nil, config)
+	return msg
+}
+
+func (r *tfResource) plan(prior tftypes.Value, private []byte, config tftypes.Value) (tftypes.Value, *tfprotov6.PlanResourceChangeResponse, string) {
+	r.h.t.Helper()
+	proposed := proposedNewState(r.schema.Block, prior, config)
+	resp, err := r.h.server.PlanResourceChange(r.h.ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         r.typeName,
+		PriorState:       r.h.dynamicValue(r.typ, prior),
+		ProposedNewState: r.h.dynamicValue(r.typ, proposed),
+		Config:           r.h.dynamicValue(r.typ, config),
+		PriorPrivate:     private,
+	})
+	if err != nil {
+		r.h.t.Fatal(err)
+	}
+	return r.h.value(r.typ, resp.PlannedState), resp, diagnosticErrors(resp.Diagnostics)
+}
+
+func (r *tfResource) applyPlan(step string, prior tftypes.Value, planResp *tfprotov6.PlanResourceChangeResponse, planned, config tftypes.Value) {
+	r.h.t.Helper()
+	resp, err := r.h.server.ApplyResourceChange(r.h.ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       r.typeName,
+		PriorState:     r.h.dynamicValue(r.typ, prior),
+		PlannedState:   r.h.dynamicValue(r.typ, planned),
+		Config:         r.h.dynamicValue(r.typ, config),
+		PlannedPrivate: planResp.PlannedPrivate,
+	})
+	if err != nil {
+		r.h.t.Fatal(err)
+	}
+	r.h.check(step+": apply", resp.Diagnostics)
+	applied := r.h.value(r.typ, resp.NewState)
+	if !config.IsNull() {
+		if unknown := unknownPaths(applied); len(unknown) > 0 {
+			r.h.t.Fatalf("%s: applied state has unknown values at %s", step, strings.Join(unknown, ", "))
+		}
+		if err := checkPlannedValues(tftypes.NewAttributePath(), planned, applied); err != nil {
+			r.h.t.Fatalf("%s: provider produced inconsistent result after apply: %v", step, err)
+		}
+	}
+	r.state, r.private = applied, resp.Private
+}
+
+// apply plans config against the current state and applies it, replacing
+// the object when the plan requires it.
+func (r *tfResource) apply(step string, config tftypes.Value) {
+	r.h.t.Helper()
+	planned, resp, msg := r.plan(r.state, r.private, config)
+	if msg != "" {
+		r.h.t.Fatalf("%s: plan: %s", step, msg)
+	}
+	if !r.state.IsNull() && planned.Equal(r.state) {
+		return // Terraform does not apply an empty plan
+	}
+	if len(resp.RequiresReplace) > 0 && !r.state.IsNull() {
+		r.destroy(step + ": replace")
+		planned, resp, msg = r.plan(r.state, r.private, config)
+		if msg != "" {
+			r.h.t.Fatalf("%s: plan after destroy: %s", step, msg)
+		}
+	}
+	r.applyPlan(step, r.state, resp, planned, config)
+}
+
+// applyError plans config like apply but expects applying it to fail, and
+// returns the errors. The state is left unchanged.
+func (r *tfResource) applyError(step string, config tftypes.Value) string {
+	r.h.t.Helper()
+	planned, planResp, msg := r.plan(r.state, r.private, config)
+	if msg != "" {
+		r.h.t.Fatalf("%s: plan: %s", step, msg)
+	}
+	resp, err := r.h.server.ApplyResourceChange(r.h.ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       r.typeName,
+		PriorState:     r.h.dynamicValue(r.typ, r.state),
+		PlannedState:   r.h.dynamicValue(r.typ, planned),
+		Config:         r.h.dynamicValue(r.typ, config),
+		PlannedPrivate: planResp.PlannedPrivate,
+	})
+	if err != nil {
+		r.h.t.Fatal(err)
+	}
+	return diagnosticErrors(resp.Diagnostics)
+}
+
+func (r *tfResource) destroy(step string) {
+	r.h.t.Helper()
+	null := tftypes.NewValue(r.typ, nil)
+	planned, resp, msg := r.plan(r.state, r.private, null)
+	if msg != "" {
+		r.h.t.Fatalf("%s: plan destroy: %s", step, msg)
+	}
+	r.applyPlan(step+": destroy", r.state, resp, planned, null)
+	if !r.state.IsNull() {
+		r.h.t.Fatalf("%s: state not removed on destroy: %v", step, r.state)
+	}
+}
+
+func (r *tfResource) refresh(step string) {
+	r.h.t.Helper()
+	resp, err := r.h.server.ReadResource(r.h.ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     r.typeName,
+		CurrentState: r.h.dynamicValue(r.typ, r.state),
+		Private:      r.private,
+	})
+	if err != nil {
+		r.h.t.Fatal(err)
+	}
+	r.h.check(step+": refresh", resp.Diagnostics)
+	state := r.h.value(r.typ, resp.NewState)
+	if state.IsNull() {
+		r.h.t.Fatalf("%s: object disappeared on refresh", step)
+	}
+	r.state, r.private = state, resp.Private
+}
+
+// importState imports id the way terraform import does: ImportResourceState
+// followed by a refresh.
+func (r *tfResource) importState(step, id string) {
+	r.h.t.Helper()
+	resp, err := r.h.server.ImportResourceState(r.h.ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: r.typeName,
+		ID:       id,
+	})
+	if err != nil {
+		r.h.t.Fatal(err)
+	}
+	r.h.check(step+": import", resp.Diagnostics)
+	if len(resp.ImportedResources) != 1 {
+		r.h.t.Fatalf("%s: imported %d resources, want 1", step, len(resp.ImportedResources))
+	}
+	imported := resp.ImportedResources[0]
+	r.state, r.private = r.h.value(r.typ, imported.State), imported.Private
+	r.refresh(step)
+}
+
+// stateString returns the string attribute name of the current state.
+func (r *tfResource) stateString(name string) string {
+	r.h.t.Helper()
+	var attrs map[string]tftypes.Value
+	var s string
+	if err := r.state.As(&attrs); err != nil {
+		r.h.t.Fatal(err)
+	}
+	if err := attrs[name].As(&s); err != nil {
+		r.h.t.Fatalf("%s: %v", name, err)
+	}
+	return s
+}
+
+// changes refreshes and returns the attribute paths config then plans to
+// change.
+func (r *tfResource) changes(step string, config tftypes.Value) []string {
+	r.h.t.Helper()
+	r.refresh(step)
+	planned, resp, msg := r.plan(r.state, r.private, config)
+	if msg != "" {
+		r.h.t.Fatalf("%s: plan: %s", step, msg)
+	}
+	if len(resp.RequiresReplace) == 0 && planned.Equal(r.state) {
+		return nil
+	}
+	diffs, _ := r.state.Diff(planned)
+	paths := []string{}
+	for _, d := range diffs {
+		paths = append(paths, d.Path.String())
+	}
+	return paths
+}
+
+// expectNoChanges refreshes and fails if config then plans any change.
+func (r *tfResource) expectNoChanges(step string, config tftypes.Value) {
+	r.h.t.Helper()
+	if paths := r.changes(step, config); paths != nil {
+		r.h.t.Fatalf("%s: expected an empty plan, got changes to %s", step, strings.Join(paths, ", "))
+	}
+}
+
+// proposedNewState mimics Terraform core: the configuration, with computed
+// attributes the configuration leaves null taken from the prior state.
+func proposedNewState(block *tfprotov6.SchemaBlock, prior, config tftypes.Value) tftypes.Value {
+	if prior.IsNull() || config.IsNull() || !config.IsKnown() {
+		return config
+	}
+	var priorAttrs, configAttrs map[string]tftypes.Value
+	if err := prior.As(&priorAttrs); err != nil {
+		panic(err)
+	}
+	if err := config.As(&configAttrs); err != nil {
+		panic(err)
+	}
+	out := map[string]tftypes.Value{}
+	for k, v := range configAttrs {
+		out[k] = v
+	}
+	for _, a := range block.Attributes {
+		if a.Computed && configAttrs[a.Name].IsNull() {
+			out[a.Name] = priorAttrs[a.Name]
+		}
+	}
+	for _, b := range block.BlockTypes {
+		if b.Nesting == tfprotov6.SchemaNestedBlockNestingModeSingle {
+			out[b.TypeName] = proposedNewState(b.Block, priorAttrs[b.TypeName], configAttrs[b.TypeName])
+		}
+	}
+	return tftypes.NewValue(config.Type(), out)
+}
+
+// checkPlannedValues reports the first known planned value that the applied
+// state does not match.
+func checkPlannedValues(p *tftypes.AttributePath, planned, applied tftypes.Value) error {
+	if !planned.IsKnown() {
+		return nil
+	}
+	if obj, ok := planned.Type().(tftypes.Object); ok && !planned.IsNull() && !applied.IsNull() {
+		var pa, aa map[string]tftypes.Value
+		if err := planned.As(&pa); err != nil {
+			return err
+		}
+		if err := applied.As(&aa); err != nil {
+			return err
+		}
+		for name := range obj.AttributeTypes {
+			if err := checkPlannedValues(p.WithAttributeName(name), pa[name], aa[name]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if !planned.IsFullyKnown() {
+		return nil
+	}
+	if !planned.Equal(applied) {
+		return fmt.Errorf("%s: planned %v, applied %v", p, planned, applied)
+	}
+	return nil
+}
+
+func unknownPaths(v tftypes.Value) []string {
+	var out []string
+	_ = tftypes.Walk(v, func(p *tftypes.AttributePath, v tftypes.Value) (bool, error) {
+		if !v.IsKnown() {
+			out = append(out, p.String())
+			return false, nil
+		}
+		return true, nil
+	})
+	return out
+}
+
+// nullAttributes returns a null value for every attribute of typ.
+func nullAttributes(typ tftypes.Object) map[string]tftypes.Value {
+	out := map[string]tftypes.Value{}
+	for name, t := range typ.AttributeTypes {
+		out[name] = tftypes.NewValue(t, nil)
+	}
+	return out
+}
+
+// tfValue converts v into a value of typ. It accepts nil, tftypes.Value,
+// string, int, bool, []string, []int and, for objects,
+// map[string]interface{} with missing attributes left null.
+func tfValue(typ tftypes.Type, v interface{}) (tftypes.Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return tftypes.NewValue(typ, nil), nil
+	case tftypes.Value:
+		return v, nil
+	case string, bool:
+		return tftypes.NewValue(typ, v), nil
+	case int:
+		return tftypes.NewValue(typ, big.NewFloat(float64(v))), nil
+	case []string, []int:
+		var elemType tftypes.Type
+		switch t := typ.(type) {
+		case tftypes.Set:
+			elemType = t.ElementType
+		case tftypes.List:
+			elemType = t.ElementType
+		default:
+			return tftypes.Value{}, fmt.Errorf("cannot use %T as %s", v, typ)
+		}
+		elems := []tftypes.Value{}
+		switch v := v.(type) {
+		case []string:
+			for _, e := range v {
+				elems = append(elems, tftypes.NewValue(elemType, e))
+			}
+		case []int:
+			for _, e := range v {
+				elems = append(elems, tftypes.NewValue(elemType, big.NewFloat(float64(e))))
+			}
+		}
+		return tftypes.NewValue(typ, elems), nil
+	case map[string]interface{}:
+		obj, ok := typ.(tftypes.Object)
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("cannot use an object as %s", typ)
+		}
+		for name := range v {
+			if _, ok := obj.AttributeTypes[name]; !ok {
+				return tftypes.Value{}, fmt.Errorf("unknown attribute %q", name)
+			}
+		}
+		attrs := map[string]tftypes.Value{}
+		for name, t := range obj.AttributeTypes {
+			av, err := tfValue(t, v[name])
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("%s: %w", name, err)
+			}
+			attrs[name] = av
+		}
+		return tftypes.NewValue(typ, attrs), nil
+	}
+	return tftypes.Value{}, fmt.Errorf("unsupported value %T", v)
+}
+
+// goValue converts v into plain Go values for comparisons: nil, string,
+// bool, int64 or float64, []interface{} and map[string]interface{}. Unknown
+// values become nil.
+func goValue(v tftypes.Value) interface{} {
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+	typ := v.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		_ = v.As(&s)
+		return s
+	case typ.Is(tftypes.Bool):
+		var b bool
+		_ = v.As(&b)
+		return b
+	case typ.Is(tftypes.Number):
+		var n big.Float
+		_ = v.As(&n)
+		if i, acc := n.Int64(); acc == big.Exact {
+			return i
+		}
+		f, _ := n.Float64()
+		return f
+	case typ.Is(tftypes.Object{}), typ.Is(tftypes.Map{}):
+		var attrs map[string]tftypes.Value
+		_ = v.As(&attrs)
+		out := map[string]interface{}{}
+		for k, a := range attrs {
+			out[k] = goValue(a)
+		}
+		return out
+	}
+	var elems []tftypes.Value
+	_ = v.As(&elems)
+	out := []interface{}{}
+	for _, e := range elems {
+		out = append(out, goValue(e))
+	}
+	return out
+}
+
+// emptyValue is the "set but empty" value of an attribute type: "", 0,
+// false or an empty collection.
+func emptyValue(typ tftypes.Type) tftypes.Value {
+	switch {
+	case typ.Is(tftypes.String):
+		return tftypes.NewValue(typ, "")
+	case typ.Is(tftypes.Number):
+		return tftypes.NewValue(typ, big.NewFloat(0))
+	case typ.Is(tftypes.Bool):
+		return tftypes.NewValue(typ, false)
+	}
+	return tftypes.NewValue(typ, []tftypes.Value{})
+}
+
+// emptyOptionals sets every optional attribute of block, and of its single
+// nested blocks other than timeouts, to its empty value.
+func emptyOptionals(block *tfprotov6.SchemaBlock) map[string]interface{} {
+	out := map[string]interface{}{}
+	for _, a := range block.Attributes {
+		if a.Optional {
+			out[a.Name] = emptyValue(a.Type)
+		}
+	}
+	for _, b := range block.BlockTypes {
+		if b.Nesting == tfprotov6.SchemaNestedBlockNestingModeSingle && b.TypeName != "timeouts" {
+			out[b.TypeName] = emptyOptionals(b.Block)
+		}
+	}
+	return out
+}
@@ -2,8 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	pschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -15,20 +20,35 @@ import (
 // -----------------------------------------------------------------------------
 
 type providerModel struct {
-	APIKey    types.String `tfsdk:"api_key"`
-	APISecret types.String `tfsdk:"api_secret"`
-	OrgID     types.String `tfsdk:"org_id"`
+	APIKey             types.String `tfsdk:"api_key"`
+	APISecret          types.String `tfsdk:"api_secret"`
+	OrgID              types.String `tfsdk:"org_id"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	RequestTimeout     types.String `tfsdk:"request_timeout"`
+	UserAgentSuffix    types.String `tfsdk:"user_agent_suffix"`
 }
 
-type umbrellaProvider struct{ client *apiClient }
+type umbrellaProvider struct {
+	client  *apiClient
+	version string
+}
 
-func NewProvider() provider.Provider { return &umbrellaProvider{} }
+// NewProvider returns a provider factory stamped with the build version.
+func NewProvider(version string) func() provider.Provider {
+	return func() provider.Provider { return &umbrellaProvider{version: version} }
+}
 
 // -----------------------------------------------------------------------------
 // Provider metadata & schema
 // -----------------------------------------------------------------------------
 func (p *umbrellaProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "umbrella"
+	resp.Version = p.version
 }
 
 func (p *umbrellaProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
@@ -38,6 +58,38 @@ func (p *umbrellaProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 			"api_key":    pschema.StringAttribute{Required: true, Sensitive: true, Description: "Umbrella API key (client ID)."},
 			"api_secret": pschema.StringAttribute{Required: true, Sensitive: true, Description: "Umbrella API secret (client secret)."},
 			"org_id":     pschema.StringAttribute{Required: true, Description: "Umbrella organisation ID."},
+			"proxy_url": pschema.StringAttribute{
+				Optional:    true,
+				Description: "HTTP(S) proxy URL for API requests. Defaults to the HTTPS_PROXY / NO_PROXY environment.",
+			},
+			"ca_cert_file": pschema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM CA bundle trusted in addition to the system roots, e.g. for a TLS-inspecting proxy.",
+			},
+			"ca_cert_pem": pschema.StringAttribute{
+				Optional:    true,
+				Description: "PEM CA bundle trusted in addition to the system roots. Conflicts with ca_cert_file.",
+			},
+			"client_cert_file": pschema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM client certificate for mutual TLS. Requires client_key_file.",
+			},
+			"client_key_file": pschema.StringAttribute{
+				Optional:    true,
+				Description: "Path to the PEM private key for client_cert_file.",
+			},
+			"insecure_skip_verify": pschema.BoolAttribute{
+				Optional:    true,
+				Description: "Disable TLS certificate verification. Only for troubleshooting; never use in production.",
+			},
+			"request_timeout": pschema.StringAttribute{
+				Optional:    true,
+				Description: "Timeout for a single HTTP request as a Go duration, e.g. \"30s\". Defaults to 15s. It applies to every request regardless of resource timeouts, so raise it too when single requests are slow.",
+			},
+			"user_agent_suffix": pschema.StringAttribute{
+				Optional:    true,
+				Description: "Text appended to the User-Agent header, e.g. a team or pipeline identifier.",
+			},
 		},
 	}
 }
@@ -52,16 +104,96 @@ func (p *umbrellaProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	client, err := newAPIClient(ctx, cfg.APIKey.ValueString(), cfg.APISecret.ValueString(), cfg.OrgID.ValueString())
+	// Credentials may come from another resource and stay unknown until apply.
+	// Planning can proceed without them; the client authenticates lazily on
+	// its first call, by which time Terraform has re-configured the provider.
+	if !cfg.APIKey.IsUnknown() && cfg.APIKey.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("api_key"), "Missing API key", "api_key must not be empty.")
+	}
+	if !cfg.APISecret.IsUnknown() && cfg.APISecret.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("api_secret"), "Missing API secret", "api_secret must not be empty.")
+	}
+	if !cfg.OrgID.IsUnknown() && cfg.OrgID.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("org_id"), "Missing organisation ID", "org_id must not be empty.")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := httpOptionsFromConfig(cfg, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	httpClient, err := newHTTPClient(opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to authenticate", err.Error())
+		resp.Diagnostics.AddError("Invalid HTTP client configuration", err.Error())
 		return
 	}
+
+	ua := buildUserAgent(p.version, req.TerraformVersion, cfg.UserAgentSuffix.ValueString())
+	client := newAPIClient(cfg.APIKey.ValueString(), cfg.APISecret.ValueString(), cfg.OrgID.ValueString(), httpClient, ua)
 	p.client = client
 	resp.ResourceData = client
 	resp.DataSourceData = client
 }
 
+// httpOptionsFromConfig translates the proxy, TLS and timeout attributes, reading any
+// referenced files.
+func httpOptionsFromConfig(cfg providerModel, diags *diag.Diagnostics) httpOptions {
+	opts := httpOptions{ProxyURL: cfg.ProxyURL.ValueString()}
+
+	if cfg.CACertFile.ValueString() != "" && cfg.CACertPEM.ValueString() != "" {
+		diags.AddAttributeError(path.Root("ca_cert_pem"), "Conflicting CA configuration", "Set only one of ca_cert_file and ca_cert_pem.")
+		return opts
+	}
+	if f := cfg.CACertFile.ValueString(); f != "" {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			diags.AddAttributeError(path.Root("ca_cert_file"), "Unable to read CA bundle", err.Error())
+			return opts
+		}
+		opts.CACertPEM = b
+	} else if pem := cfg.CACertPEM.ValueString(); pem != "" {
+		opts.CACertPEM = []byte(pem)
+	}
+
+	certFile, keyFile := cfg.ClientCertFile.ValueString(), cfg.ClientKeyFile.ValueString()
+	if (certFile == "") != (keyFile == "") {
+		diags.AddAttributeError(path.Root("client_cert_file"), "Incomplete client certificate", "client_cert_file and client_key_file must be set together.")
+		return opts
+	}
+	if certFile != "" {
+		cert, err := os.ReadFile(certFile)
+		if err != nil {
+			diags.AddAttributeError(path.Root("client_cert_file"), "Unable to read client certificate", err.Error())
+			return opts
+		}
+		key, err := os.ReadFile(keyFile)
+		if err != nil {
+			diags.AddAttributeError(path.Root("client_key_file"), "Unable to read client key", err.Error())
+			return opts
+		}
+		opts.ClientCertPEM, opts.ClientKeyPEM = cert, key
+	}
+
+	if cfg.InsecureSkipVerify.ValueBool() {
+		opts.InsecureSkipVerify = true
+		diags.AddAttributeWarning(path.Root("insecure_skip_verify"), "TLS certificate verification is DISABLED",
+			"insecure_skip_verify = true lets anyone able to intercept traffic read your Umbrella API credentials and "+
+				"tamper with policy changes. Use ca_cert_file or ca_cert_pem to trust your proxy's CA instead.")
+	}
+
+	if t := cfg.RequestTimeout.ValueString(); t != "" {
+		d, err := time.ParseDuration(t)
+		if err != nil || d <= 0 {
+			diags.AddAttributeError(path.Root("request_timeout"), "Invalid request timeout", fmt.Sprintf("%q is not a positive duration such as \"30s\".", t))
+			return opts
+		}
+		opts.Timeout = d
+	}
+	return opts
+}
+
 // -----------------------------------------------------------------------------
 // Provider resources & data-sources
 // -----------------------------------------------------------------------------
@@ -73,6 +205,33 @@ func (p *umbrellaProvider) Resources(_ context.Context) []func() resource.Resour
 		NewSAMLResource,
 		NewRulesetResource,
 		NewRuleResource,
+		NewSiteResource,
+		NewNetworkResource,
+		NewInternalNetworkResource,
+		NewInternalDomainResource,
+		NewDNSPolicyResource,
+		NewContentCategorySettingResource,
+		NewSecuritySettingResource,
+		NewSelectiveDecryptionListResource,
+		NewBlockPageResource,
+		NewWarnPageResource,
+		NewBypassCodeResource,
+		NewBypassUserResource,
+		NewRoamingComputerTagsResource,
+		NewTagResource,
+		NewTagDevicesResource,
+		NewVirtualApplianceResource,
+	}
+}
+func (p *umbrellaProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		NewChildOrganizationsDataSource,
+		NewSitesDataSource,
+		NewNetworksDataSource,
+		NewDNSPoliciesDataSource,
+		NewCategoriesDataSource,
+		NewApplicationsDataSource,
+		NewRoamingComputersDataSource,
+		NewVirtualAppliancesDataSource,
 	}
 }
-func (p *umbrellaProvider) DataSources(_ context.Context) []func() datasource.DataSource { return nil }
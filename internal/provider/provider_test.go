@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestConfigureWithUnknownCredentials covers credentials that come from
+// another resource: configuring must succeed, and the first API call must
+// fail with a diagnostic rather than a panic.
+func TestConfigureWithUnknownCredentials(t *testing.T) {
+	ctx := context.Background()
+	server := providerserver.NewProtocol6(NewProvider("test")())()
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &tfHarness{t: t, ctx: ctx, server: server, schemas: schemaResp.ResourceSchemas}
+
+	providerType := schemaResp.Provider.ValueType().(tftypes.Object)
+	cfg := nullAttributes(providerType)
+	cfg["api_key"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	cfg["api_secret"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	cfg["org_id"] = tftypes.NewValue(tftypes.String, "1234")
+	configResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: h.dynamicValue(providerType, tftypes.NewValue(providerType, cfg)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.check("configure provider", configResp.Diagnostics)
+
+	r := h.resource("umbrella_destination_list")
+	attrs := nullAttributes(r.typ)
+	attrs["id"] = tftypes.NewValue(tftypes.String, "100")
+	attrs["name"] = tftypes.NewValue(tftypes.String, "blocked")
+	attrs["type"] = tftypes.NewValue(tftypes.String, "DOMAIN")
+	readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     r.typeName,
+		CurrentState: h.dynamicValue(r.typ, tftypes.NewValue(r.typ, attrs)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg := diagnosticErrors(readResp.Diagnostics); !strings.Contains(msg, "API credentials are not known yet") {
+		t.Errorf("read with unknown credentials: got %q, want an error about unknown credentials", msg)
+	}
+}
+
+func writeTestFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// optionsFromConfig runs httpOptionsFromConfig and returns its diagnostics.
+func optionsFromConfig(t *testing.T, cfg providerModel) (httpOptions, diag.Diagnostics) {
+	t.Helper()
+	var diags diag.Diagnostics
+	opts := httpOptionsFromConfig(cfg, &diags)
+	return opts, diags
+}
+
+func TestHTTPOptionsCABundle(t *testing.T) {
+	ca := newTestCA(t)
+	srv := newTLSServer(t, ca, nil)
+
+	for name, cfg := range map[string]providerModel{
+		"ca_cert_pem":  {CACertPEM: types.StringValue(string(ca.certPEM))},
+		"ca_cert_file": {CACertFile: types.StringValue(writeTestFile(t, "ca.pem", ca.certPEM))},
+	} {
+		t.Run(name, func(t *testing.T) {
+			opts, diags := optionsFromConfig(t, cfg)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			c, err := newHTTPClient(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := getStatus(c, srv.URL); err != nil {
+				t.Fatalf("CA from %s not trusted: %v", name, err)
+			}
+		})
+	}
+}
+
+func TestHTTPOptionsClientCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	srv := newTLSServer(t, ca, ca.pool())
+	certPEM, keyPEM, _ := ca.leaf(t, "terraform", x509.ExtKeyUsageClientAuth)
+
+	opts, diags := optionsFromConfig(t, providerModel{
+		CACertPEM:      types.StringValue(string(ca.certPEM)),
+		ClientCertFile: types.StringValue(writeTestFile(t, "client.pem", certPEM)),
+		ClientKeyFile:  types.StringValue(writeTestFile(t, "client-key.pem", keyPEM)),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	c, err := newHTTPClient(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := getStatus(c, srv.URL); err != nil {
+		t.Fatalf("mTLS request: %v", err)
+	}
+}
+
+func TestHTTPOptionsInsecureSkipVerifyWarns(t *testing.T) {
+	opts, diags := optionsFromConfig(t, providerModel{InsecureSkipVerify: types.BoolValue(true)})
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if !opts.InsecureSkipVerify {
+		t.Error("InsecureSkipVerify not passed through")
+	}
+	if diags.WarningsCount() != 1 || !strings.Contains(diags.Warnings()[0].Summary(), "DISABLED") {
+		t.Errorf("want one loud warning, got %v", diags)
+	}
+
+	_, diags = optionsFromConfig(t, providerModel{InsecureSkipVerify: types.BoolValue(false)})
+	if len(diags) != 0 {
+		t.Errorf("no diagnostics expected when verification is on, got %v", diags)
+	}
+}
+
+func TestHTTPOptionsProxyAndTimeout(t *testing.T) {
+	opts, diags := optionsFromConfig(t, providerModel{
+		ProxyURL:       types.StringValue("http://proxy.example.com:3128"),
+		RequestTimeout: types.StringValue("45s"),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if opts.ProxyURL != "http://proxy.example.com:3128" {
+		t.Errorf("proxy %q not passed through", opts.ProxyURL)
+	}
+	if opts.Timeout != 45*time.Second {
+		t.Errorf("timeout %s, want 45s", opts.Timeout)
+	}
+}
+
+func TestHTTPOptionsInvalid(t *testing.T) {
+	ca := newTestCA(t)
+	missing := filepath.Join(t.TempDir(), "missing.pem")
+
+	for name, cfg := range map[string]providerModel{
+		"both CA attributes": {
+			CACertFile: types.StringValue(writeTestFile(t, "ca.pem", ca.certPEM)),
+			CACertPEM:  types.StringValue(string(ca.certPEM)),
+		},
+		"missing CA file":         {CACertFile: types.StringValue(missing)},
+		"client cert without key": {ClientCertFile: types.StringValue(writeTestFile(t, "client.pem", ca.certPEM))},
+		"missing client cert":     {ClientCertFile: types.StringValue(missing), ClientKeyFile: types.StringValue(missing)},
+		"unparsable timeout":      {RequestTimeout: types.StringValue("soon")},
+		"zero timeout":            {RequestTimeout: types.StringValue("0s")},
+		"negative timeout":        {RequestTimeout: types.StringValue("-5s")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, diags := optionsFromConfig(t, cfg); !diags.HasError() {
+				t.Error("expected an error diagnostic")
+			}
+		})
+	}
+
+	// Bad proxy URLs and PEM content are rejected when the client is built.
+	for name, cfg := range map[string]providerModel{
+		"proxy without scheme": {ProxyURL: types.StringValue("proxy.example.com:3128")},
+		"CA PEM without certs": {CACertPEM: types.StringValue("-----BEGIN NOTHING-----")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			opts, diags := optionsFromConfig(t, cfg)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if _, err := newHTTPClient(opts); err == nil {
+				t.Error("expected newHTTPClient to fail")
+			}
+		})
+	}
+}
+
+func TestBuildUserAgent(t *testing.T) {
+	platform := regexp.QuoteMeta(fmt.Sprintf("(%s/%s; %s)", runtime.GOOS, runtime.GOARCH, runtime.Version()))
+	for name, tc := range map[string]struct {
+		terraformVersion, suffix, appended string
+		want                               string
+	}{
+		"provider only": {want: `^terraform-provider-umbrella/1\.2\.3 ` + platform + `$`},
+		"terraform":     {terraformVersion: "1.6.0", want: `^Terraform/1\.6\.0 \(\+https://www\.terraform\.io\) terraform-provider-umbrella/1\.2\.3 ` + platform + `$`},
+		"suffixes":      {suffix: " team-net ", appended: "pipeline/42", want: `^terraform-provider-umbrella/1\.2\.3 ` + platform + ` pipeline/42 team-net$`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("TF_APPEND_USER_AGENT", tc.appended)
+			if got := buildUserAgent("1.2.3", tc.terraformVersion, tc.suffix); !regexp.MustCompile(tc.want).MatchString(got) {
+				t.Errorf("got %q, want a match for %s", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestUserAgentSentToUmbrella configures the real provider, built with
+// version 1.2.3, and checks the header both the token and API requests carry.
+func TestUserAgentSentToUmbrella(t *testing.T) {
+	t.Setenv("TF_APPEND_USER_AGENT", "")
+	f := newFakeUmbrella(t)
+	p := NewProvider("1.2.3")().(*umbrellaProvider)
+	ctx := context.Background()
+	server := providerserver.NewProtocol6(p)()
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &tfHarness{t: t, ctx: ctx, server: server, schemas: schemaResp.ResourceSchemas, dataSchemas: schemaResp.DataSourceSchemas}
+
+	providerType := schemaResp.Provider.ValueType().(tftypes.Object)
+	cfg := nullAttributes(providerType)
+	for name, v := range map[string]string{"api_key": "key", "api_secret": "secret", "org_id": "1234", "user_agent_suffix": "team-net"} {
+		cfg[name] = tftypes.NewValue(tftypes.String, v)
+	}
+	configResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "1.6.0",
+		Config:           h.dynamicValue(providerType, tftypes.NewValue(providerType, cfg)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.check("configure provider", configResp.Diagnostics)
+	p.client.client.Transport = f.api.client.client.Transport
+	h.readData("umbrella_child_organizations", nil)
+
+	want := regexp.MustCompile(`^Terraform/1\.6\.0 \(\+https://www\.terraform\.io\) terraform-provider-umbrella/1\.2\.3 \([^)]+\) team-net$`)
+	got := f.received(http.MethodGet, "^"+childOrgsPath+"$")
+	if len(got) != 1 {
+		t.Fatalf("%d child organisation requests, want 1", len(got))
+	}
+	if !want.MatchString(got[0].userAgent) {
+		t.Errorf("API request User-Agent %q, want a match for %s", got[0].userAgent, want)
+	}
+	f.api.tokenMu.Lock()
+	defer f.api.tokenMu.Unlock()
+	if !want.MatchString(f.api.tokenUserAgent) {
+		t.Errorf("token request User-Agent %q, want a match for %s", f.api.tokenUserAgent, want)
+	}
+}
@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Resource: umbrella_block_page
+// -----------------------------------------------------------------------------
+
+type blockPageResource struct{ client *apiClient }
+
+type blockPageModel struct {
+	ID            types.String   `tfsdk:"id"`
+	OrgID         types.String   `tfsdk:"org_id"`
+	Name          types.String   `tfsdk:"name"`
+	Message       types.String   `tfsdk:"message"`
+	AdminContact  types.String   `tfsdk:"admin_contact"`
+	LogoFile      types.String   `tfsdk:"logo_file"`
+	LogoSHA256    types.String   `tfsdk:"logo_sha256"`
+	BypassUserIDs types.Set      `tfsdk:"bypass_user_ids"`
+	BypassCodeIDs types.Set      `tfsdk:"bypass_code_ids"`
+	CreatedAt     types.String   `tfsdk:"created_at"`
+	UpdatedAt     types.String   `tfsdk:"updated_at"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// blockPage is the policies API representation of a block page appearance.
+type blockPage struct {
+	ID            int64    `json:"id"`
+	Name          string   `json:"name"`
+	Message       string   `json:"message"`
+	AdminContact  string   `json:"adminContact"`
+	BypassUserIDs []string `json:"bypassUserIds"`
+	BypassCodeIDs []string `json:"bypassCodeIds"`
+	CreatedAt     string   `json:"createdAt"`
+	ModifiedAt    string   `json:"modifiedAt"`
+}
+
+func NewBlockPageResource() resource.Resource { return &blockPageResource{} }
+
+func (r *blockPageResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "umbrella_block_page"
+}
+
+func (r *blockPageResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*apiClient)
+}
+
+func (r *blockPageResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Umbrella Block Page appearance shown to blocked users",
+		Attributes: map[string]schema.Attribute{
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Block page ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name":            schema.StringAttribute{Required: true, Description: "Block page name"},
+			"message":         schema.StringAttribute{Optional: true, Description: "Message shown on the block page"},
+			"admin_contact":   schema.StringAttribute{Optional: true, Description: "Administrator email address users can contact"},
+			"logo_file":       schema.StringAttribute{Optional: true, Description: "Path to a local PNG, JPEG or GIF logo uploaded with the page"},
+			"logo_sha256":     schema.StringAttribute{Computed: true, Description: "SHA-256 of the uploaded logo; changes to the file trigger a new upload"},
+			"bypass_user_ids": schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "Bypass user IDs allowed to bypass the page"},
+			"bypass_code_ids": schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "Bypass code IDs accepted by the page"},
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation timestamp",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"updated_at": schema.StringAttribute{Computed: true, Description: "Last update timestamp"},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *blockPageResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planLogoSHA256(ctx, req, resp)
+}
+
+// ------------------ CRUD ------------------
+
+func (r *blockPageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan blockPageModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload := plan.payload(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out blockPage
+	if err := r.client.doJSON(ctx, http.MethodPost, fmt.Sprintf(blockPagesPath, orgID), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(out.ID, 10))
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *blockPageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state blockPageModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
+
+	var out blockPage
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(blockPagesPath+"/%s", orgID, state.ID.ValueString()), nil, &out); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+	out.toModel(&state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *blockPageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state blockPageModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload := plan.payload(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out blockPage
+	if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(blockPagesPath+"/%s", orgID, state.ID.ValueString()), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *blockPageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state blockPageModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	err := r.client.doJSON(ctx, http.MethodDelete, fmt.Sprintf(blockPagesPath+"/%s", orgID, state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+// ------------------ helpers ------------------
+
+// payload also records the SHA-256 of the uploaded logo on m.
+func (m *blockPageModel) payload(ctx context.Context, diags *diag.Diagnostics) map[string]interface{} {
+	return map[string]interface{}{
+		"name":          m.Name.ValueString(),
+		"message":       m.Message.ValueString(),
+		"adminContact":  m.AdminContact.ValueString(),
+		"logo":          pageLogoPayload(m.LogoFile, &m.LogoSHA256, diags),
+		"bypassUserIds": setToStringSlice(ctx, m.BypassUserIDs, diags),
+		"bypassCodeIds": setToStringSlice(ctx, m.BypassCodeIDs, diags),
+	}
+}
+
+// toModel leaves logo_file and logo_sha256 alone: the API does not return the
+// logo, so its state always reflects the last upload.
+func (p blockPage) toModel(m *blockPageModel) {
+	m.Name = types.StringValue(p.Name)
+	m.Message = stringValueOrNull(m.Message, p.Message)
+	m.AdminContact = stringValueOrNull(m.AdminContact, p.AdminContact)
+	m.BypassUserIDs = stringSetValue(m.BypassUserIDs, p.BypassUserIDs)
+	m.BypassCodeIDs = stringSetValue(m.BypassCodeIDs, p.BypassCodeIDs)
+	m.CreatedAt = types.StringValue(p.CreatedAt)
+	m.UpdatedAt = types.StringValue(p.ModifiedAt)
+}
+
+// pageLogo is the wire format of a block or warn page logo upload.
+type pageLogo struct {
+	ContentType string `json:"contentType"`
+	Data        string `json:"data"`
+}
+
+// maxPageLogoSize is the largest logo Umbrella accepts on a block page.
+const maxPageLogoSize = 1 << 20
+
+// readPageLogo loads and checks a logo file, returning it ready for upload
+// along with its SHA-256.
+func readPageLogo(name string) (*pageLogo, string, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(b) > maxPageLogoSize {
+		return nil, "", fmt.Errorf("%s is %d bytes; logos are limited to %d bytes", name, len(b), maxPageLogoSize)
+	}
+	contentType := http.DetectContentType(b)
+	switch contentType {
+	case "image/png", "image/jpeg", "image/gif":
+	default:
+		return nil, "", fmt.Errorf("%s is %s; logos must be PNG, JPEG or GIF", name, contentType)
+	}
+	sum := sha256.Sum256(b)
+	return &pageLogo{ContentType: contentType, Data: base64.StdEncoding.EncodeToString(b)}, hex.EncodeToString(sum[:]), nil
+}
+
+// pageLogoPayload returns the logo to upload, or nil to use Cisco's default,
+// and stores its SHA-256 in sum.
+func pageLogoPayload(logoFile types.String, sum *types.String, diags *diag.Diagnostics) *pageLogo {
+	if logoFile.IsNull() {
+		*sum = types.StringNull()
+		return nil
+	}
+	logo, s, err := readPageLogo(logoFile.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("logo_file"), "Invalid logo", err.Error())
+		return nil
+	}
+	*sum = types.StringValue(s)
+	return logo
+}
+
+// planLogoSHA256 hashes logo_file at plan time so replacing the file's
+// contents under the same path still plans an upload.
+func planLogoSHA256(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var logoFile types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("logo_file"), &logoFile)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sum := types.StringUnknown()
+	switch {
+	case logoFile.IsNull():
+		sum = types.StringNull()
+	case !logoFile.IsUnknown():
+		_, s, err := readPageLogo(logoFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("logo_file"), "Invalid logo", err.Error())
+			return
+		}
+		sum = types.StringValue(s)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("logo_sha256"), sum)...)
+}
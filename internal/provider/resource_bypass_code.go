@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Resource: umbrella_bypass_code
+// -----------------------------------------------------------------------------
+
+type bypassCodeResource struct{ client *apiClient }
+
+type bypassCodeModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	OrgID              types.String   `tfsdk:"org_id"`
+	Description        types.String   `tfsdk:"description"`
+	ValidFor           types.String   `tfsdk:"valid_for"`
+	Categories         types.Set      `tfsdk:"categories"`
+	DestinationListIDs types.Set      `tfsdk:"destination_list_ids"`
+	Code               types.String   `tfsdk:"code"`
+	ExpiresAt          types.String   `tfsdk:"expires_at"`
+	CreatedAt          types.String   `tfsdk:"created_at"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// bypassCode is the policies API representation of a bypass code.
+type bypassCode struct {
+	ID                 int64    `json:"id"`
+	Description        string   `json:"description"`
+	Code               string   `json:"code"`
+	Categories         []int64  `json:"categories"`
+	DestinationListIDs []string `json:"destinationListIds"`
+	ExpiresAt          string   `json:"expiresAt"`
+	CreatedAt          string   `json:"createdAt"`
+}
+
+func NewBypassCodeResource() resource.Resource { return &bypassCodeResource{} }
+
+func (r *bypassCodeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "umbrella_bypass_code"
+}
+
+func (r *bypassCodeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*apiClient)
+}
+
+func (r *bypassCodeResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Umbrella Block Page Bypass Code. Expired codes are replaced with a new code on the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Bypass code ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"description": schema.StringAttribute{Optional: true, Description: "Who or what the code is for"},
+			"valid_for": schema.StringAttribute{
+				Required:      true,
+				Description:   "How long each generated code stays valid, e.g. \"72h\"",
+				Validators:    []validator.String{durationValidator{}},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"categories":           schema.SetAttribute{Optional: true, ElementType: types.Int64Type, Description: "Content category IDs the code unblocks"},
+			"destination_list_ids": schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "Destination list IDs the code unblocks"},
+			"code": schema.StringAttribute{
+				Computed:      true,
+				Sensitive:     true,
+				Description:   "Generated bypass code",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Expiry timestamp in RFC 3339 format",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation timestamp",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+// ModifyPlan plans the replacement of a code that has expired, so a fresh
+// code is generated instead of leaving a dead one in state.
+func (r *bypassCodeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var expiresAt types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("expires_at"), &expiresAt)...)
+	if resp.Diagnostics.HasError() || expiresAt.IsNull() {
+		return
+	}
+	expiry, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+	if err != nil || time.Now().Before(expiry) {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expires_at"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("code"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expires_at"))
+}
+
+// ------------------ CRUD ------------------
+
+func (r *bypassCodeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan bypassCodeModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	validFor, err := time.ParseDuration(plan.ValidFor.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("valid_for"), "Create failed", err.Error())
+		return
+	}
+	payload := plan.payload(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	payload["expiresAt"] = time.Now().UTC().Add(validFor).Format(time.RFC3339)
+
+	var out bypassCode
+	if err := r.client.doJSON(ctx, http.MethodPost, fmt.Sprintf(bypassCodesPath, orgID), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(out.ID, 10))
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *bypassCodeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state bypassCodeModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
+
+	var out bypassCode
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(bypassCodesPath+"/%s", orgID, state.ID.ValueString()), nil, &out); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+	out.toModel(&state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *bypassCodeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state bypassCodeModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	// The code and its expiry are fixed once generated; only what it
+	// unblocks can change in place.
+	payload := plan.payload(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	payload["expiresAt"] = state.ExpiresAt.ValueString()
+
+	var out bypassCode
+	if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(bypassCodesPath+"/%s", orgID, state.ID.ValueString()), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *bypassCodeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state bypassCodeModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	err := r.client.doJSON(ctx, http.MethodDelete, fmt.Sprintf(bypassCodesPath+"/%s", orgID, state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+// ------------------ helpers ------------------
+
+func (m bypassCodeModel) payload(ctx context.Context, diags *diag.Diagnostics) map[string]interface{} {
+	return map[string]interface{}{
+		"description":        m.Description.ValueString(),
+		"categories":         setToInt64Slice(ctx, m.Categories, diags),
+		"destinationListIds": setToStringSlice(ctx, m.DestinationListIDs, diags),
+	}
+}
+
+func (c bypassCode) toModel(m *bypassCodeModel) {
+	m.Description = stringValueOrNull(m.Description, c.Description)
+	m.Categories = int64SetValue(m.Categories, c.Categories)
+	m.DestinationListIDs = stringSetValue(m.DestinationListIDs, c.DestinationListIDs)
+	// Umbrella may only reveal the code when it is generated; keep the one in
+	// state once known.
+	if c.Code != "" || m.Code.IsUnknown() {
+		m.Code = types.StringValue(c.Code)
+	}
+	m.ExpiresAt = types.StringValue(c.ExpiresAt)
+	m.CreatedAt = types.StringValue(c.CreatedAt)
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// TestBypassCodeExpiry checks that a code Umbrella reports as expired plans
+// a replacement with a new code and expiry, and that applying it generates
+// a fresh code.
+func TestBypassCodeExpiry(t *testing.T) {
+	f := newFakeUmbrella(t)
+	r := newTFHarness(t, f.api.client).resource("umbrella_bypass_code")
+	config := r.config(map[string]interface{}{"description": "contractors", "valid_for": "72h"})
+	r.apply("create", config)
+	r.expectNoChanges("after create", config)
+
+	id := r.stateString("id")
+	expired := map[string]interface{}{}
+	for k, v := range f.object(bypassCodesPath+"/%s", "1234", id) {
+		expired[k] = v
+	}
+	expired["expiresAt"] = time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	f.seed(expired, bypassCodesPath+"/%s", "1234", id)
+
+	r.refresh("expired")
+	planned, resp, msg := r.plan(r.state, r.private, config)
+	if msg != "" {
+		t.Fatalf("expired: plan: %s", msg)
+	}
+	if len(resp.RequiresReplace) == 0 {
+		t.Fatal("expired: plan does not replace the code")
+	}
+	if got := strings.Join(unknownPaths(planned), ", "); !strings.Contains(got, "code") || !strings.Contains(got, "expires_at") {
+		t.Errorf("expired: unknown planned values at %s, want code and expires_at", got)
+	}
+
+	r.apply("replace", config)
+	if r.stateString("id") == id {
+		t.Error("replace: the expired code was not replaced")
+	}
+	if posts := f.received(http.MethodPost, `/bypasscodes$`); len(posts) != 2 {
+		t.Errorf("replace: %d codes created, want 2", len(posts))
+	}
+	expiry, err := time.Parse(time.RFC3339, r.stateString("expires_at"))
+	if err != nil || !expiry.After(time.Now()) {
+		t.Errorf("replace: expires_at = %q, want a future time", r.stateString("expires_at"))
+	}
+	r.expectNoChanges("after replace", config)
+	r.destroy("destroy")
+}
+
+// TestLogsNeverContainBypassCodes runs a bypass code through its lifecycle
+// with TRACE logging captured and checks the generated code is masked.
+func TestLogsNeverContainBypassCodes(t *testing.T) {
+	f := newFakeUmbrella(t)
+	var logs bytes.Buffer
+	h := newTFHarness(t, f.api.client)
+	h.ctx = tflogtest.RootLogger(context.Background(), &logs)
+
+	r := h.resource("umbrella_bypass_code")
+	attrs := map[string]interface{}{"description": "contractors", "valid_for": "24h"}
+	r.apply("create", r.config(attrs))
+	attrs["description"] = "vendors"
+	r.apply("update", r.config(attrs))
+	r.refresh("refresh")
+	code := r.stateString("code")
+	r.destroy("destroy")
+
+	out := logs.String()
+	if !strings.Contains(out, "API response body") {
+		t.Fatalf("bypass code responses were not logged at TRACE:\n%s", out)
+	}
+	if strings.Contains(out, code) {
+		t.Errorf("bypass code %q found in logs", code)
+	}
+}
@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Resource: umbrella_bypass_user
+// -----------------------------------------------------------------------------
+
+type bypassUserResource struct{ client *apiClient }
+
+type bypassUserModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	OrgID              types.String   `tfsdk:"org_id"`
+	Email              types.String   `tfsdk:"email"`
+	Categories         types.Set      `tfsdk:"categories"`
+	DestinationListIDs types.Set      `tfsdk:"destination_list_ids"`
+	CreatedAt          types.String   `tfsdk:"created_at"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// bypassUser is the policies API representation of a block page bypass user.
+type bypassUser struct {
+	ID                 int64    `json:"id"`
+	Email              string   `json:"email"`
+	Categories         []int64  `json:"categories"`
+	DestinationListIDs []string `json:"destinationListIds"`
+	CreatedAt          string   `json:"createdAt"`
+}
+
+func NewBypassUserResource() resource.Resource { return &bypassUserResource{} }
+
+func (r *bypassUserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "umbrella_bypass_user"
+}
+
+func (r *bypassUserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*apiClient)
+}
+
+func (r *bypassUserResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Umbrella Block Page Bypass User",
+		Attributes: map[string]schema.Attribute{
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Bypass user ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"email": schema.StringAttribute{
+				Required:      true,
+				Description:   "Email address of the user allowed to bypass the block page",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"categories":           schema.SetAttribute{Optional: true, ElementType: types.Int64Type, Description: "Content category IDs the user may bypass"},
+			"destination_list_ids": schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "Destination list IDs the user may bypass"},
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation timestamp",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+// ------------------ CRUD ------------------
+
+func (r *bypassUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan bypassUserModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload := plan.payload(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out bypassUser
+	if err := r.client.doJSON(ctx, http.MethodPost, fmt.Sprintf(bypassUsersPath, orgID), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(out.ID, 10))
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *bypassUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state bypassUserModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
+
+	var out bypassUser
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(bypassUsersPath+"/%s", orgID, state.ID.ValueString()), nil, &out); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+	out.toModel(&state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *bypassUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state bypassUserModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload := plan.payload(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out bypassUser
+	if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(bypassUsersPath+"/%s", orgID, state.ID.ValueString()), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *bypassUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state bypassUserModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	err := r.client.doJSON(ctx, http.MethodDelete, fmt.Sprintf(bypassUsersPath+"/%s", orgID, state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+// ------------------ helpers ------------------
+
+func (m bypassUserModel) payload(ctx context.Context, diags *diag.Diagnostics) map[string]interface{} {
+	return map[string]interface{}{
+		"email":              m.Email.ValueString(),
+		"categories":         setToInt64Slice(ctx, m.Categories, diags),
+		"destinationListIds": setToStringSlice(ctx, m.DestinationListIDs, diags),
+	}
+}
+
+func (u bypassUser) toModel(m *bypassUserModel) {
+	m.Email = types.StringValue(u.Email)
+	m.Categories = int64SetValue(m.Categories, u.Categories)
+	m.DestinationListIDs = stringSetValue(m.DestinationListIDs, u.DestinationListIDs)
+	m.CreatedAt = types.StringValue(u.CreatedAt)
+}
@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Resource: umbrella_content_category_setting
+// -----------------------------------------------------------------------------
+
+type contentCategorySettingResource struct{ client *apiClient }
+
+type contentCategorySettingModel struct {
+	ID         types.String   `tfsdk:"id"`
+	OrgID      types.String   `tfsdk:"org_id"`
+	Name       types.String   `tfsdk:"name"`
+	Categories types.Set      `tfsdk:"categories"`
+	CreatedAt  types.String   `tfsdk:"created_at"`
+	UpdatedAt  types.String   `tfsdk:"updated_at"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+// contentCategorySetting is the policies API representation of a content
+// category setting.
+type contentCategorySetting struct {
+	ID         int64   `json:"id"`
+	Name       string  `json:"name"`
+	Categories []int64 `json:"categories"`
+	CreatedAt  string  `json:"createdAt"`
+	ModifiedAt string  `json:"modifiedAt"`
+}
+
+func NewContentCategorySettingResource() resource.Resource { return &contentCategorySettingResource{} }
+
+func (r *contentCategorySettingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "umbrella_content_category_setting"
+}
+
+func (r *contentCategorySettingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*apiClient)
+}
+
+func (r *contentCategorySettingResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Umbrella Content Category Setting (named set of blocked content categories)",
+		Attributes: map[string]schema.Attribute{
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Content category setting ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name":       schema.StringAttribute{Required: true, Description: "Content category setting name"},
+			"categories": schema.SetAttribute{ElementType: types.Int64Type, Required: true, Description: "Blocked content category IDs, see the umbrella_categories data source"},
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation timestamp",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"updated_at": schema.StringAttribute{Computed: true, Description: "Last update timestamp"},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+// ------------------ CRUD ------------------
+
+func (r *contentCategorySettingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan contentCategorySettingModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload := map[string]interface{}{
+		"name":       plan.Name.ValueString(),
+		"categories": setToInt64Slice(ctx, plan.Categories, &resp.Diagnostics),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out contentCategorySetting
+	if err := r.client.doJSON(ctx, http.MethodPost, fmt.Sprintf(contentCategorySettingsPath, orgID), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(out.ID, 10))
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *contentCategorySettingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state contentCategorySettingModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
+
+	var out contentCategorySetting
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(contentCategorySettingsPath+"/%s", orgID, state.ID.ValueString()), nil, &out); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+	out.toModel(&state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *contentCategorySettingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state contentCategorySettingModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload := map[string]interface{}{
+		"name":       plan.Name.ValueString(),
+		"categories": setToInt64Slice(ctx, plan.Categories, &resp.Diagnostics),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out contentCategorySetting
+	if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(contentCategorySettingsPath+"/%s", orgID, state.ID.ValueString()), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *contentCategorySettingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state contentCategorySettingModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	err := r.client.doJSON(ctx, http.MethodDelete, fmt.Sprintf(contentCategorySettingsPath+"/%s", orgID, state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+// ------------------ helpers ------------------
+
+func (s contentCategorySetting) toModel(m *contentCategorySettingModel) {
+	m.Name = types.StringValue(s.Name)
+	m.Categories = int64SetValue(m.Categories, s.Categories)
+	m.CreatedAt = types.StringValue(s.CreatedAt)
+	m.UpdatedAt = types.StringValue(s.ModifiedAt)
+}
+
+// contentCategorySettingID resolves the name of a content category setting,
+// as used by umbrella_rule, to its ID.
+func (c *apiClient) contentCategorySettingID(ctx context.Context, orgID, name string) (string, error) {
+	settings, err := getAllPages[contentCategorySetting](ctx, c, fmt.Sprintf(contentCategorySettingsPath, orgID))
+	if err != nil {
+		return "", err
+	}
+	for _, s := range settings {
+		if s.Name == name {
+			return strconv.FormatInt(s.ID, 10), nil
+		}
+	}
+	return "", fmt.Errorf("content category setting %q not found", name)
+}
+
+// contentCategorySettingName returns the name of the content category setting
+// with the given ID.
+func (c *apiClient) contentCategorySettingName(ctx context.Context, orgID, id string) (string, error) {
+	var s contentCategorySetting
+	if err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf(contentCategorySettingsPath+"/%s", orgID, id), nil, &s); err != nil {
+		return "", err
+	}
+	return s.Name, nil
+}
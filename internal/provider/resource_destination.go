@@ -2,10 +2,10 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -22,10 +22,12 @@ type destinationResource struct {
 }
 
 type destinationModel struct {
-	ID                types.String `tfsdk:"id"`
-	DestinationListID types.String `tfsdk:"destination_list_id"`
-	Destination       types.String `tfsdk:"destination"`
-	Comment           types.String `tfsdk:"comment"`
+	ID                types.String   `tfsdk:"id"`
+	OrgID             types.String   `tfsdk:"org_id"`
+	DestinationListID types.String   `tfsdk:"destination_list_id"`
+	Destination       types.String   `tfsdk:"destination"`
+	Comment           types.String   `tfsdk:"comment"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewDestinationResource() resource.Resource {
@@ -38,16 +40,16 @@ func (r *destinationResource) Metadata(_ context.Context, req resource.MetadataR
 
 func (r *destinationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
-		resp.Diagnostics.AddError("Missing provider data", "internal: no client")
 		return
 	}
 	r.client = req.ProviderData.(*apiClient)
 }
 
-func (r *destinationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *destinationResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages individual destinations within an Umbrella destination list",
 		Attributes: map[string]schema.Attribute{
+			"org_id": orgIDAttribute(),
 			"id": schema.StringAttribute{
 				Computed:    true,
 				Description: "The unique identifier for this destination",
@@ -71,6 +73,9 @@ func (r *destinationResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Description: "Optional comment for this destination",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -83,6 +88,17 @@ func (r *destinationResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
 	// Create the destination entry
 	entry := map[string]string{
 		"destination": plan.Destination.ValueString(),
@@ -92,18 +108,10 @@ func (r *destinationResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	entries := []map[string]string{entry}
-	body, _ := json.Marshal(entries)
 
-	path := fmt.Sprintf(destListPath+"/%s/destinations", r.client.orgID, plan.DestinationListID.ValueString())
-	apiResp, err := r.client.do(ctx, http.MethodPost, path, body)
-	if err != nil {
-		resp.Diagnostics.AddError("API error", err.Error())
-		return
-	}
-	defer apiResp.Body.Close()
-
-	if apiResp.StatusCode != http.StatusCreated && apiResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("Create failed", fmt.Sprintf("HTTP %s", apiResp.Status))
+	path := fmt.Sprintf(destListPath+"/%s/destinations", orgID, plan.DestinationListID.ValueString())
+	if err := r.client.doJSON(ctx, http.MethodPost, path, entries, nil); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
 		return
 	}
 
@@ -121,9 +129,24 @@ func (r *destinationResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
+
 	// Get all destinations from the list and find our specific destination
-	destinations, err := r.getDestinationsFromList(ctx, state.DestinationListID.ValueString())
+	destinations, err := r.getDestinationsFromList(ctx, orgID, state.DestinationListID.ValueString())
 	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Failed to read destinations", err.Error())
 		return
 	}
@@ -133,12 +156,7 @@ func (r *destinationResource) Read(ctx context.Context, req resource.ReadRequest
 	for _, dest := range destinations {
 		if dest.Destination == state.Destination.ValueString() {
 			found = true
-			// Update comment if it exists
-			if dest.Comment != "" {
-				state.Comment = types.StringValue(dest.Comment)
-			} else {
-				state.Comment = types.StringNull()
-			}
+			state.Comment = stringValueOrNull(state.Comment, dest.Comment)
 			break
 		}
 	}
@@ -160,11 +178,22 @@ func (r *destinationResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
 	// For updates, we need to delete the old destination and add the new one
 	// since the Umbrella API doesn't support direct destination updates
 
 	// First, remove the old destination
-	if err := r.removeDestination(ctx, state.DestinationListID.ValueString(), state.Destination.ValueString()); err != nil {
+	if err := r.removeDestination(ctx, orgID, state.DestinationListID.ValueString(), state.Destination.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Failed to remove old destination", err.Error())
 		return
 	}
@@ -178,20 +207,12 @@ func (r *destinationResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	entries := []map[string]string{entry}
-	body, _ := json.Marshal(entries)
 
-	path := fmt.Sprintf(destListPath+"/%s/destinations", r.client.orgID, plan.DestinationListID.ValueString())
-	apiResp, err := r.client.do(ctx, http.MethodPost, path, body)
-	if err != nil {
+	path := fmt.Sprintf(destListPath+"/%s/destinations", orgID, plan.DestinationListID.ValueString())
+	if err := r.client.doJSON(ctx, http.MethodPost, path, entries, nil); err != nil {
 		resp.Diagnostics.AddError("Failed to add updated destination", err.Error())
 		return
 	}
-	defer apiResp.Body.Close()
-
-	if apiResp.StatusCode != http.StatusCreated && apiResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("Update failed", fmt.Sprintf("HTTP %s", apiResp.Status))
-		return
-	}
 
 	// Update the ID if the destination value changed
 	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", plan.DestinationListID.ValueString(), plan.Destination.ValueString()))
@@ -206,7 +227,18 @@ func (r *destinationResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	if err := r.removeDestination(ctx, state.DestinationListID.ValueString(), state.Destination.ValueString()); err != nil {
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	err := r.removeDestination(ctx, orgID, state.DestinationListID.ValueString(), state.Destination.ValueString())
+	if err != nil && !isNotFound(err) {
 		resp.Diagnostics.AddError("Failed to delete destination", err.Error())
 	}
 }
@@ -220,43 +252,16 @@ type destinationEntry struct {
 }
 
 // getDestinationsFromList retrieves all destinations from a specific destination list
-func (r *destinationResource) getDestinationsFromList(ctx context.Context, listID string) ([]destinationEntry, error) {
-	path := fmt.Sprintf(destListPath+"/%s/destinations", r.client.orgID, listID)
-	resp, err := r.client.do(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("destinations GET %s", resp.Status)
-	}
-
-	var destinations []destinationEntry
-	if err := json.NewDecoder(resp.Body).Decode(&destinations); err != nil {
-		return nil, err
-	}
-
-	return destinations, nil
+func (r *destinationResource) getDestinationsFromList(ctx context.Context, orgID, listID string) ([]destinationEntry, error) {
+	return getPages[destinationEntry](ctx, r.client, fmt.Sprintf(destListPath+"/%s/destinations", orgID, listID), destinationsPageLimit)
 }
 
 // removeDestination removes a specific destination from a destination list
-func (r *destinationResource) removeDestination(ctx context.Context, listID, destination string) error {
+func (r *destinationResource) removeDestination(ctx context.Context, orgID, listID, destination string) error {
 	entries := []map[string]string{
 		{"destination": destination},
 	}
-	body, _ := json.Marshal(entries)
-
-	path := fmt.Sprintf(destListPath+"/%s/destinations", r.client.orgID, listID)
-	resp, err := r.client.do(ctx, http.MethodDelete, path, body)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("delete destination HTTP %s", resp.Status)
-	}
 
-	return nil
+	path := fmt.Sprintf(destListPath+"/%s/destinations", orgID, listID)
+	return r.client.doJSON(ctx, http.MethodDelete, path, entries, nil)
 }
@@ -2,13 +2,14 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
-	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -19,10 +20,12 @@ import (
 type destinationListResource struct{ client *apiClient }
 
 type destListModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Type         types.String `tfsdk:"type"`
-	Destinations types.Set    `tfsdk:"destinations"`
+	ID           types.String   `tfsdk:"id"`
+	OrgID        types.String   `tfsdk:"org_id"`
+	Name         types.String   `tfsdk:"name"`
+	Type         types.String   `tfsdk:"type"`
+	Destinations types.Set      `tfsdk:"destinations"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewDestinationListResource() resource.Resource { return &destinationListResource{} }
@@ -33,24 +36,31 @@ func (r *destinationListResource) Metadata(_ context.Context, req resource.Metad
 
 func (r *destinationListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
-		resp.Diagnostics.AddError("Missing provider data", "internal: no client")
 		return
 	}
 	r.client = req.ProviderData.(*apiClient)
 }
 
-func (r *destinationListResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *destinationListResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Umbrella Destination List (allow, block or SAML-bypass)",
 		Attributes: map[string]schema.Attribute{
-			"id":           schema.StringAttribute{Computed: true},
+			"org_id":       orgIDAttribute(),
+			"id":           schema.StringAttribute{Computed: true, PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
 			"name":         schema.StringAttribute{Required: true},
 			"type":         schema.StringAttribute{Required: true, Description: "URL | CIDR | DOMAIN"},
 			"destinations": schema.SetAttribute{Optional: true, ElementType: types.StringType},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
+func (r *destinationListResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateWithOrgID(ctx, req, resp)
+}
+
 // ------------------ CRUD ------------------
 
 func (r *destinationListResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -59,23 +69,24 @@ func (r *destinationListResource) Create(ctx context.Context, req resource.Creat
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	payload := map[string]string{"name": plan.Name.ValueString(), "type": plan.Type.ValueString()}
-	body, _ := json.Marshal(payload)
-	apiResp, err := r.client.do(ctx, http.MethodPost, fmt.Sprintf(destListPath, r.client.orgID), body)
-	if err != nil {
-		resp.Diagnostics.AddError("API error", err.Error())
-		return
-	}
-	defer apiResp.Body.Close()
-	if apiResp.StatusCode != http.StatusCreated && apiResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("Create failed", fmt.Sprintf("HTTP %s", apiResp.Status))
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload := map[string]string{"name": plan.Name.ValueString(), "type": plan.Type.ValueString()}
 	var data struct {
 		ID int `json:"id"`
 	}
-	if err := json.NewDecoder(apiResp.Body).Decode(&data); err != nil {
-		resp.Diagnostics.AddError("decode", err.Error())
+	if err := r.client.doJSON(ctx, http.MethodPost, fmt.Sprintf(destListPath, orgID), payload, &data); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
 		return
 	}
 	plan.ID = types.StringValue(fmt.Sprintf("%d", data.ID))
@@ -84,7 +95,7 @@ func (r *destinationListResource) Create(ctx context.Context, req resource.Creat
 	if !plan.Destinations.IsNull() {
 		dests := setToStringSlice(ctx, plan.Destinations, &resp.Diagnostics)
 		if len(dests) > 0 {
-			if err := r.syncDestinations(ctx, plan.ID.ValueString(), nil, dests); err != nil {
+			if err := r.syncDestinations(ctx, orgID, plan.ID.ValueString(), nil, dests); err != nil {
 				resp.Diagnostics.AddError("add destinations", err.Error())
 				return
 			}
@@ -99,34 +110,22 @@ func (r *destinationListResource) Read(ctx context.Context, req resource.ReadReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	apiResp, err := r.client.do(ctx, http.MethodGet, fmt.Sprintf(destListPath+"/%s", r.client.orgID, state.ID.ValueString()), nil)
-	if err != nil || apiResp.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-	var dl struct {
-		Name string `json:"name"`
-		Type string `json:"type"`
-	}
-	if err := json.NewDecoder(apiResp.Body).Decode(&dl); err != nil {
-		resp.Diagnostics.AddError("decode", err.Error())
-		return
-	}
-	state.Name = types.StringValue(dl.Name)
-	state.Type = types.StringValue(dl.Type)
 
-	// fetch destinations
-	dests, err := r.getDestinations(ctx, state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("destinations", err.Error())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	elems := []attr.Value{}
-	for _, d := range dests {
-		elems = append(elems, types.StringValue(d))
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	if err := r.readList(ctx, &state); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
 	}
-	state.Destinations, _ = types.SetValue(types.StringType, elems)
-
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -137,12 +136,23 @@ func (r *destinationListResource) Update(ctx context.Context, req resource.Updat
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
 	// Update name/type if changed
 	if plan.Name != state.Name || plan.Type != state.Type {
 		payload := map[string]string{"name": plan.Name.ValueString(), "type": plan.Type.ValueString()}
-		b, _ := json.Marshal(payload)
-		if _, err := r.client.do(ctx, http.MethodPut, fmt.Sprintf(destListPath+"/%s", r.client.orgID, state.ID.ValueString()), b); err != nil {
-			resp.Diagnostics.AddError("update list", err.Error())
+		if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(destListPath+"/%s", orgID, state.ID.ValueString()), payload, nil); err != nil {
+			resp.Diagnostics.AddError("Update failed", err.Error())
 			return
 		}
 	}
@@ -153,11 +163,18 @@ func (r *destinationListResource) Update(ctx context.Context, req resource.Updat
 
 	toAdd, toDel := diffSlices(current, desired)
 	if len(toAdd) > 0 || len(toDel) > 0 {
-		if err := r.syncDestinations(ctx, state.ID.ValueString(), toDel, toAdd); err != nil {
+		if err := r.syncDestinations(ctx, orgID, state.ID.ValueString(), toDel, toAdd); err != nil {
 			resp.Diagnostics.AddError("sync destinations", err.Error())
 			return
 		}
 	}
+
+	// Read back so state reflects what Umbrella stored, not what we sent.
+	plan.ID = state.ID
+	if err := r.readList(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError("Read after update failed", err.Error())
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
@@ -167,26 +184,53 @@ func (r *destinationListResource) Delete(ctx context.Context, req resource.Delet
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if _, err := r.client.do(ctx, http.MethodDelete, fmt.Sprintf(destListPath+"/%s", r.client.orgID, state.ID.ValueString()), nil); err != nil {
-		resp.Diagnostics.AddError("delete", err.Error())
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	err := r.client.doJSON(ctx, http.MethodDelete, fmt.Sprintf(destListPath+"/%s", orgID, state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
 	}
 }
 
 // ------------------ helpers ------------------
 
-func (r *destinationListResource) getDestinations(ctx context.Context, listID string) ([]string, error) {
-	path := fmt.Sprintf(destListPath+"/%s/destinations", r.client.orgID, listID)
-	resp, err := r.client.do(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
+// readList refreshes m (identified by m.ID and m.OrgID) from the API. A
+// missing list is reported as an *apiError with a 404 status.
+func (r *destinationListResource) readList(ctx context.Context, m *destListModel) error {
+	orgID := r.client.resolveOrgID(m.OrgID)
+	var dl struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
 	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("destinations GET %s", resp.Status)
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(destListPath+"/%s", orgID, m.ID.ValueString()), nil, &dl); err != nil {
+		return err
 	}
-	var out []struct {
-		Destination string `json:"destination"`
+
+	dests, err := r.getDestinations(ctx, orgID, m.ID.ValueString())
+	if err != nil {
+		return err
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+	m.OrgID = types.StringValue(orgID)
+	m.Name = types.StringValue(dl.Name)
+	m.Type = types.StringValue(dl.Type)
+	m.Destinations = stringSetValue(m.Destinations, dests)
+	return nil
+}
+
+func (r *destinationListResource) getDestinations(ctx context.Context, orgID, listID string) ([]string, error) {
+	out, err := getPages[struct {
+		Destination string `json:"destination"`
+	}](ctx, r.client, fmt.Sprintf(destListPath+"/%s/destinations", orgID, listID), destinationsPageLimit)
+	if err != nil {
 		return nil, err
 	}
 	vals := []string{}
@@ -196,19 +240,15 @@ func (r *destinationListResource) getDestinations(ctx context.Context, listID st
 	return vals, nil
 }
 
-func (r *destinationListResource) syncDestinations(ctx context.Context, listID string, remove []string, add []string) error {
+func (r *destinationListResource) syncDestinations(ctx context.Context, orgID, listID string, remove []string, add []string) error {
+	path := fmt.Sprintf(destListPath+"/%s/destinations", orgID, listID)
 	if len(add) > 0 {
 		entries := []map[string]string{}
 		for _, d := range add {
 			entries = append(entries, map[string]string{"destination": d})
 		}
-		b, _ := json.Marshal(entries)
-		path := fmt.Sprintf(destListPath+"/%s/destinations", r.client.orgID, listID)
-		if resp, err := r.client.do(ctx, http.MethodPost, path, b); err != nil || (resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK) {
-			if err == nil {
-				err = fmt.Errorf("add destinations HTTP %s", resp.Status)
-			}
-			return err
+		if err := r.client.doJSON(ctx, http.MethodPost, path, entries, nil); err != nil {
+			return fmt.Errorf("add destinations: %w", err)
 		}
 	}
 	if len(remove) > 0 {
@@ -216,13 +256,8 @@ func (r *destinationListResource) syncDestinations(ctx context.Context, listID s
 		for _, d := range remove {
 			entries = append(entries, map[string]string{"destination": d})
 		}
-		b, _ := json.Marshal(entries)
-		path := fmt.Sprintf(destListPath+"/%s/destinations", r.client.orgID, listID)
-		if resp, err := r.client.do(ctx, http.MethodDelete, path, b); err != nil || (resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK) {
-			if err == nil {
-				err = fmt.Errorf("delete destinations HTTP %s", resp.Status)
-			}
-			return err
+		if err := r.client.doJSON(ctx, http.MethodDelete, path, entries, nil); err != nil {
+			return fmt.Errorf("delete destinations: %w", err)
 		}
 	}
 	return nil
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// TestDestinationsReadEveryPage checks that lists longer than one page of
+// destinations are read in full by both destination resources.
+func TestDestinationsReadEveryPage(t *testing.T) {
+	f := newFakeUmbrella(t)
+	list := fmt.Sprintf(destListPath+"/100", "1234")
+	f.seed(map[string]interface{}{"id": 100, "name": "feed", "type": "DOMAIN"}, list)
+	const n = 2*destinationsPageLimit + 50
+	for i := 0; i < n; i++ {
+		f.members[list] = append(f.members[list], map[string]interface{}{"id": i, "destination": fmt.Sprintf("d%03d.example.com", i)})
+	}
+	ctx := context.Background()
+
+	dests, err := (&destinationListResource{client: f.api.client}).getDestinations(ctx, "1234", "100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dests) != n {
+		t.Errorf("destination list read %d destinations, want %d", len(dests), n)
+	}
+
+	entries, err := (&destinationResource{client: f.api.client}).getDestinationsFromList(ctx, "1234", "100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != n || entries[n-1].Destination != fmt.Sprintf("d%03d.example.com", n-1) {
+		t.Errorf("destination read %d entries, want %d ending with the last page", len(entries), n)
+	}
+}
@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Resource: umbrella_dns_policy
+// -----------------------------------------------------------------------------
+
+type dnsPolicyResource struct{ client *apiClient }
+
+type dnsPolicyModel struct {
+	ID                       types.String            `tfsdk:"id"`
+	OrgID                    types.String            `tfsdk:"org_id"`
+	Name                     types.String            `tfsdk:"name"`
+	Description              types.String            `tfsdk:"description"`
+	Priority                 types.Int64             `tfsdk:"priority"`
+	SecuritySettingID        types.String            `tfsdk:"security_setting_id"`
+	ContentCategorySettingID types.String            `tfsdk:"content_category_setting_id"`
+	BlockPageID              types.String            `tfsdk:"block_page_id"`
+	WarnPageID               types.String            `tfsdk:"warn_page_id"`
+	DestinationListIDs       types.Set               `tfsdk:"destination_list_ids"`
+	Identities               *rulesetIdentitiesModel `tfsdk:"identities"`
+	IsDefault                types.Bool              `tfsdk:"is_default"`
+	CreatedAt                types.String            `tfsdk:"created_at"`
+	UpdatedAt                types.String            `tfsdk:"updated_at"`
+	Timeouts                 timeouts.Value          `tfsdk:"timeouts"`
+}
+
+// dnsPolicy is the policies API representation of a DNS policy.
+type dnsPolicy struct {
+	ID                       string            `json:"id"`
+	Name                     string            `json:"name"`
+	Description              string            `json:"description"`
+	Priority                 int64             `json:"priority"`
+	SecuritySettingID        string            `json:"securitySettingId"`
+	ContentCategorySettingID string            `json:"contentCategorySettingId"`
+	BlockPageID              string            `json:"blockPageId"`
+	WarnPageID               string            `json:"warnPageId"`
+	DestinationListIDs       []string          `json:"destinationListIds"`
+	Identities               rulesetIdentities `json:"identities"`
+	IsDefault                bool              `json:"isDefault"`
+	CreatedAt                string            `json:"createdAt"`
+	ModifiedAt               string            `json:"modifiedAt"`
+}
+
+func NewDNSPolicyResource() resource.Resource { return &dnsPolicyResource{} }
+
+func (r *dnsPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "umbrella_dns_policy"
+}
+
+func (r *dnsPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*apiClient)
+}
+
+func (r *dnsPolicyResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Umbrella DNS Policy",
+		Attributes: map[string]schema.Attribute{
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "DNS policy ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name":        schema.StringAttribute{Required: true, Description: "DNS policy name"},
+			"description": schema.StringAttribute{Optional: true, Description: "DNS policy description"},
+			"priority": schema.Int64Attribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "Evaluation order among DNS policies (lower numbers = higher priority). Assigned by Umbrella when omitted.",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"security_setting_id":         schema.StringAttribute{Optional: true, Description: "Security setting ID applied by the policy"},
+			"content_category_setting_id": schema.StringAttribute{Optional: true, Description: "Content category setting ID applied by the policy"},
+			"block_page_id":               schema.StringAttribute{Optional: true, Description: "Block page appearance ID shown to blocked users"},
+			"warn_page_id":                schema.StringAttribute{Optional: true, Description: "Warn page appearance ID shown for warned destinations"},
+			"destination_list_ids":        schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "Destination list IDs attached to the policy"},
+			"is_default":                  schema.BoolAttribute{Computed: true, Description: "Whether this is the organisation's default DNS policy"},
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation timestamp",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"updated_at": schema.StringAttribute{Computed: true, Description: "Last update timestamp"},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts":   timeouts.BlockAll(ctx),
+			"identities": identitiesBlock("Identities the DNS policy applies to"),
+		},
+	}
+}
+
+// ------------------ CRUD ------------------
+
+func (r *dnsPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dnsPolicyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload := plan.payload(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out dnsPolicy
+	if err := r.client.doJSON(ctx, http.MethodPost, fmt.Sprintf(dnsPolicyPath, orgID), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(out.ID)
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *dnsPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dnsPolicyModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
+
+	var out dnsPolicy
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(dnsPolicyPath+"/%s", orgID, state.ID.ValueString()), nil, &out); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+	out.toModel(&state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *dnsPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state dnsPolicyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload := plan.payload(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out dnsPolicy
+	if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(dnsPolicyPath+"/%s", orgID, state.ID.ValueString()), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *dnsPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dnsPolicyModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	err := r.client.doJSON(ctx, http.MethodDelete, fmt.Sprintf(dnsPolicyPath+"/%s", orgID, state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+// ------------------ helpers ------------------
+
+// payload builds the full policy body; PUT replaces the policy, so every
+// managed field is sent on update as well.
+func (m dnsPolicyModel) payload(ctx context.Context, diags *diag.Diagnostics) map[string]interface{} {
+	payload := map[string]interface{}{
+		"name":               m.Name.ValueString(),
+		"description":        m.Description.ValueString(),
+		"destinationListIds": setToStringSlice(ctx, m.DestinationListIDs, diags),
+		"identities":         m.Identities.toAPI(ctx, diags),
+	}
+	if !m.SecuritySettingID.IsNull() {
+		payload["securitySettingId"] = m.SecuritySettingID.ValueString()
+	}
+	if !m.ContentCategorySettingID.IsNull() {
+		payload["contentCategorySettingId"] = m.ContentCategorySettingID.ValueString()
+	}
+	if !m.BlockPageID.IsNull() {
+		payload["blockPageId"] = m.BlockPageID.ValueString()
+	}
+	if !m.WarnPageID.IsNull() {
+		payload["warnPageId"] = m.WarnPageID.ValueString()
+	}
+	if !m.Priority.IsNull() && !m.Priority.IsUnknown() {
+		payload["priority"] = m.Priority.ValueInt64()
+	}
+	return payload
+}
+
+func (p dnsPolicy) toModel(m *dnsPolicyModel) {
+	m.Name = types.StringValue(p.Name)
+	m.Description = stringValueOrNull(m.Description, p.Description)
+	m.Priority = types.Int64Value(p.Priority)
+	m.SecuritySettingID = stringValueOrNull(m.SecuritySettingID, p.SecuritySettingID)
+	m.ContentCategorySettingID = stringValueOrNull(m.ContentCategorySettingID, p.ContentCategorySettingID)
+	m.BlockPageID = stringValueOrNull(m.BlockPageID, p.BlockPageID)
+	m.WarnPageID = stringValueOrNull(m.WarnPageID, p.WarnPageID)
+	m.DestinationListIDs = stringSetValue(m.DestinationListIDs, p.DestinationListIDs)
+	m.Identities = p.Identities.toModel(m.Identities)
+	m.IsDefault = types.BoolValue(p.IsDefault)
+	m.CreatedAt = types.StringValue(p.CreatedAt)
+	m.UpdatedAt = types.StringValue(p.ModifiedAt)
+}
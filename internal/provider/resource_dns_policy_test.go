@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestDNSPolicyOmitsUnsetSettings checks that setting IDs left null are not
+// sent, so a full PUT does not clear associations made outside Terraform.
+func TestDNSPolicyOmitsUnsetSettings(t *testing.T) {
+	f := newFakeUmbrella(t)
+	r := newTFHarness(t, f.api.client).resource("umbrella_dns_policy")
+
+	attrs := map[string]interface{}{"name": "office", "security_setting_id": "55"}
+	r.apply("create", r.config(attrs))
+	attrs["description"] = "office network"
+	r.apply("update", r.config(attrs))
+	r.expectNoChanges("after update", r.config(attrs))
+
+	for _, method := range []string{http.MethodPost, http.MethodPut} {
+		reqs := f.received(method, `/dnspolicies`)
+		if len(reqs) != 1 {
+			t.Fatalf("got %d %s requests, want 1", len(reqs), method)
+		}
+		body := reqs[0].body.(map[string]interface{})
+		if body["securitySettingId"] != "55" {
+			t.Errorf("%s: securitySettingId = %v, want 55", method, body["securitySettingId"])
+		}
+		for _, key := range []string{"contentCategorySettingId", "blockPageId", "warnPageId"} {
+			if v, ok := body[key]; ok {
+				t.Errorf("%s: %s = %v, want it left out", method, key, v)
+			}
+		}
+	}
+	r.destroy("destroy")
+}
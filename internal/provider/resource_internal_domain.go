@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Resource: umbrella_internal_domain
+// -----------------------------------------------------------------------------
+
+type internalDomainResource struct{ client *apiClient }
+
+type internalDomainModel struct {
+	ID                      types.String   `tfsdk:"id"`
+	OrgID                   types.String   `tfsdk:"org_id"`
+	Domain                  types.String   `tfsdk:"domain"`
+	Description             types.String   `tfsdk:"description"`
+	IncludeAllVAs           types.Bool     `tfsdk:"include_all_vas"`
+	IncludeAllMobileDevices types.Bool     `tfsdk:"include_all_mobile_devices"`
+	SiteIDs                 types.Set      `tfsdk:"site_ids"`
+	NetworkIDs              types.Set      `tfsdk:"network_ids"`
+	TunnelIDs               types.Set      `tfsdk:"tunnel_ids"`
+	CreatedAt               types.String   `tfsdk:"created_at"`
+	UpdatedAt               types.String   `tfsdk:"updated_at"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
+}
+
+// internalDomain is the deployments API representation of an internal domain.
+type internalDomain struct {
+	ID                      int64   `json:"id"`
+	Domain                  string  `json:"domain"`
+	Description             string  `json:"description"`
+	IncludeAllVAs           bool    `json:"includeAllVAs"`
+	IncludeAllMobileDevices bool    `json:"includeAllMobileDevices"`
+	SiteIDs                 []int64 `json:"siteIds"`
+	NetworkIDs              []int64 `json:"networkIds"`
+	TunnelIDs               []int64 `json:"tunnelIds"`
+	CreatedAt               string  `json:"createdAt"`
+	ModifiedAt              string  `json:"modifiedAt"`
+}
+
+func NewInternalDomainResource() resource.Resource { return &internalDomainResource{} }
+
+func (r *internalDomainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "umbrella_internal_domain"
+}
+
+func (r *internalDomainResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*apiClient)
+}
+
+func (r *internalDomainResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Umbrella Internal Domain (resolved by local DNS instead of Umbrella)",
+		Attributes: map[string]schema.Attribute{
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Internal domain ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"domain":      schema.StringAttribute{Required: true, Description: "Domain to bypass, e.g. corp.example.com"},
+			"description": schema.StringAttribute{Optional: true, Description: "Description of the internal domain"},
+			"include_all_vas": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Apply to all Virtual Appliances (default: false)",
+			},
+			"include_all_mobile_devices": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Apply to all roaming clients and mobile devices (default: false)",
+			},
+			"site_ids":    schema.SetAttribute{ElementType: types.Int64Type, Optional: true, Description: "Sites whose Virtual Appliances bypass this domain"},
+			"network_ids": schema.SetAttribute{ElementType: types.Int64Type, Optional: true, Description: "Network identities whose traffic bypasses this domain"},
+			"tunnel_ids":  schema.SetAttribute{ElementType: types.Int64Type, Optional: true, Description: "Tunnels whose traffic bypasses this domain"},
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation timestamp in ISO 8601 format",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"updated_at": schema.StringAttribute{Computed: true, Description: "Last update timestamp in ISO 8601 format"},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *internalDomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateWithOrgID(ctx, req, resp)
+}
+
+// ------------------ CRUD ------------------
+
+func (r *internalDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan internalDomainModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload := plan.payload(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out internalDomain
+	if err := r.client.doJSON(ctx, http.MethodPost, fmt.Sprintf(internalDomainsPath, orgID), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(out.ID, 10))
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *internalDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state internalDomainModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
+
+	var out internalDomain
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(internalDomainsPath+"/%s", orgID, state.ID.ValueString()), nil, &out); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+	out.toModel(&state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *internalDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state internalDomainModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload := plan.payload(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out internalDomain
+	if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(internalDomainsPath+"/%s", orgID, state.ID.ValueString()), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *internalDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state internalDomainModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	err := r.client.doJSON(ctx, http.MethodDelete, fmt.Sprintf(internalDomainsPath+"/%s", orgID, state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+// ------------------ helpers ------------------
+
+func (m internalDomainModel) payload(ctx context.Context, diags *diag.Diagnostics) map[string]interface{} {
+	return map[string]interface{}{
+		"domain":                  m.Domain.ValueString(),
+		"description":             m.Description.ValueString(),
+		"includeAllVAs":           m.IncludeAllVAs.ValueBool(),
+		"includeAllMobileDevices": m.IncludeAllMobileDevices.ValueBool(),
+		"siteIds":                 setToInt64Slice(ctx, m.SiteIDs, diags),
+		"networkIds":              setToInt64Slice(ctx, m.NetworkIDs, diags),
+		"tunnelIds":               setToInt64Slice(ctx, m.TunnelIDs, diags),
+	}
+}
+
+func (d internalDomain) toModel(m *internalDomainModel) {
+	m.Domain = types.StringValue(d.Domain)
+	m.Description = stringValueOrNull(m.Description, d.Description)
+	m.IncludeAllVAs = types.BoolValue(d.IncludeAllVAs)
+	m.IncludeAllMobileDevices = types.BoolValue(d.IncludeAllMobileDevices)
+	m.SiteIDs = int64SetValue(m.SiteIDs, d.SiteIDs)
+	m.NetworkIDs = int64SetValue(m.NetworkIDs, d.NetworkIDs)
+	m.TunnelIDs = int64SetValue(m.TunnelIDs, d.TunnelIDs)
+	m.CreatedAt = types.StringValue(d.CreatedAt)
+	m.UpdatedAt = types.StringValue(d.ModifiedAt)
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// TestInternalDomainAssociations checks that site, network and tunnel IDs
+// are sent to Umbrella and that clearing one sends an empty list.
+func TestInternalDomainAssociations(t *testing.T) {
+	f := newFakeUmbrella(t)
+	r := newTFHarness(t, f.api.client).resource("umbrella_internal_domain")
+
+	attrs := map[string]interface{}{
+		"domain":      "corp.example.com",
+		"site_ids":    []int{1},
+		"network_ids": []int{601, 602},
+		"tunnel_ids":  []int{7},
+	}
+	r.apply("create", r.config(attrs))
+	r.expectNoChanges("after create", r.config(attrs))
+	posts := f.received(http.MethodPost, `/internaldomains$`)
+	if len(posts) != 1 {
+		t.Fatalf("got %d POSTs, want 1", len(posts))
+	}
+	body := posts[0].body.(map[string]interface{})
+	for key, want := range map[string]string{"siteIds": "[1]", "networkIds": "[601 602]", "tunnelIds": "[7]"} {
+		if got := fmt.Sprint(body[key]); got != want {
+			t.Errorf("create: %s = %s, want %s", key, got, want)
+		}
+	}
+
+	delete(attrs, "tunnel_ids")
+	r.apply("drop tunnels", r.config(attrs))
+	r.expectNoChanges("after drop tunnels", r.config(attrs))
+	puts := f.received(http.MethodPut, `/internaldomains/[^/]+$`)
+	if len(puts) != 1 {
+		t.Fatalf("got %d PUTs, want 1", len(puts))
+	}
+	if got := puts[0].body.(map[string]interface{})["tunnelIds"]; !reflect.DeepEqual(got, []interface{}{}) {
+		t.Errorf("drop tunnels: tunnelIds = %v, want []", got)
+	}
+	r.destroy("destroy")
+}
+
+func TestInternalDomainImport(t *testing.T) {
+	f := newFakeUmbrella(t)
+	f.seed(map[string]interface{}{"id": 300, "domain": "corp.example.com", "siteIds": []interface{}{1}}, internalDomainsPath+"/300", "1234")
+	f.seed(map[string]interface{}{"id": 301, "domain": "child.example.com", "networkIds": []interface{}{601}}, internalDomainsPath+"/301", "5678")
+	h := newTFHarness(t, f.api.client)
+
+	for id, want := range map[string]map[string]interface{}{
+		"300":      {"org_id": "1234", "domain": "corp.example.com", "site_ids": []int{1}},
+		"5678/301": {"org_id": "5678", "domain": "child.example.com", "network_ids": []int{601}},
+	} {
+		r := h.resource("umbrella_internal_domain")
+		r.importState("import "+id, id)
+		if got := r.stateString("domain"); got != want["domain"] {
+			t.Errorf("import %s: domain = %q, want %q", id, got, want["domain"])
+		}
+		r.expectNoChanges("after import "+id, r.config(want))
+	}
+}
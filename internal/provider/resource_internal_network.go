@@ -0,0 +1,273 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Resource: umbrella_internal_network
+// -----------------------------------------------------------------------------
+
+type internalNetworkResource struct{ client *apiClient }
+
+type internalNetworkModel struct {
+	ID        types.String   `tfsdk:"id"`
+	OrgID     types.String   `tfsdk:"org_id"`
+	Name      types.String   `tfsdk:"name"`
+	CIDR      types.String   `tfsdk:"cidr"`
+	SiteID    types.Int64    `tfsdk:"site_id"`
+	NetworkID types.Int64    `tfsdk:"network_id"`
+	TunnelID  types.Int64    `tfsdk:"tunnel_id"`
+	CreatedAt types.String   `tfsdk:"created_at"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+// internalNetwork is the deployments API representation of an internal
+// network. Exactly one of SiteID, NetworkID and TunnelID is set.
+type internalNetwork struct {
+	OriginID     int64  `json:"originId"`
+	Name         string `json:"name"`
+	IPAddress    string `json:"ipAddress"`
+	PrefixLength int64  `json:"prefixLength"`
+	SiteID       int64  `json:"siteId"`
+	NetworkID    int64  `json:"networkId"`
+	TunnelID     int64  `json:"tunnelId"`
+	CreatedAt    string `json:"createdAt"`
+}
+
+func NewInternalNetworkResource() resource.Resource { return &internalNetworkResource{} }
+
+func (r *internalNetworkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "umbrella_internal_network"
+}
+
+func (r *internalNetworkResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*apiClient)
+}
+
+func (r *internalNetworkResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Umbrella Internal Network (private address range mapped to a site, network or tunnel)",
+		Attributes: map[string]schema.Attribute{
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Internal network origin ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{Required: true, Description: "Internal network name"},
+			"cidr": schema.StringAttribute{
+				Required:    true,
+				Description: "Private address range in CIDR notation, e.g. 10.10.0.0/16",
+				Validators:  []validator.String{cidrValidator{}},
+			},
+			"site_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Site the range belongs to (Virtual Appliance deployments). Exactly one of site_id, network_id or tunnel_id is required.",
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("network_id"), path.MatchRoot("tunnel_id")),
+				},
+			},
+			"network_id": schema.Int64Attribute{Optional: true, Description: "Network identity the range sits behind"},
+			"tunnel_id":  schema.Int64Attribute{Optional: true, Description: "Tunnel the range is routed through"},
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation timestamp in ISO 8601 format",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *internalNetworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateWithOrgID(ctx, req, resp)
+}
+
+// ------------------ CRUD ------------------
+
+func (r *internalNetworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan internalNetworkModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload, err := plan.payload()
+	if err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+	var out internalNetwork
+	if err := r.client.doJSON(ctx, http.MethodPost, fmt.Sprintf(internalNetworksPath, orgID), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(out.OriginID, 10))
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *internalNetworkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state internalNetworkModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
+
+	var out internalNetwork
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(internalNetworksPath+"/%s", orgID, state.ID.ValueString()), nil, &out); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+	out.toModel(&state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *internalNetworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state internalNetworkModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload, err := plan.payload()
+	if err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+	var out internalNetwork
+	if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(internalNetworksPath+"/%s", orgID, state.ID.ValueString()), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *internalNetworkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state internalNetworkModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	err := r.client.doJSON(ctx, http.MethodDelete, fmt.Sprintf(internalNetworksPath+"/%s", orgID, state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+// ------------------ helpers ------------------
+
+func (m internalNetworkModel) payload() (map[string]interface{}, error) {
+	_, ipNet, err := net.ParseCIDR(m.CIDR.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("invalid cidr %q: %w", m.CIDR.ValueString(), err)
+	}
+	prefixLength, _ := ipNet.Mask.Size()
+	payload := map[string]interface{}{
+		"name":         m.Name.ValueString(),
+		"ipAddress":    ipNet.IP.String(),
+		"prefixLength": prefixLength,
+	}
+	switch {
+	case !m.SiteID.IsNull():
+		payload["siteId"] = m.SiteID.ValueInt64()
+	case !m.NetworkID.IsNull():
+		payload["networkId"] = m.NetworkID.ValueInt64()
+	case !m.TunnelID.IsNull():
+		payload["tunnelId"] = m.TunnelID.ValueInt64()
+	}
+	return payload, nil
+}
+
+func (n internalNetwork) toModel(m *internalNetworkModel) {
+	m.Name = types.StringValue(n.Name)
+	m.CIDR = types.StringValue(fmt.Sprintf("%s/%d", n.IPAddress, n.PrefixLength))
+	m.SiteID = int64ValueOrNull(n.SiteID)
+	m.NetworkID = int64ValueOrNull(n.NetworkID)
+	m.TunnelID = int64ValueOrNull(n.TunnelID)
+	m.CreatedAt = types.StringValue(n.CreatedAt)
+}
+
+// int64ValueOrNull maps the API's zero "not associated" IDs to null.
+func int64ValueOrNull(v int64) types.Int64 {
+	if v == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(v)
+}
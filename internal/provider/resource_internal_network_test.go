@@ -0,0 +1,24 @@
+package provider
+
+import "testing"
+
+func TestInternalNetworkImport(t *testing.T) {
+	f := newFakeUmbrella(t)
+	f.seed(map[string]interface{}{"originId": 400, "name": "lan", "ipAddress": "10.2.0.0", "prefixLength": 24, "siteId": 1}, internalNetworksPath+"/400", "1234")
+	f.seed(map[string]interface{}{"originId": 401, "name": "dmz", "ipAddress": "172.16.0.0", "prefixLength": 16, "tunnelId": 7}, internalNetworksPath+"/401", "5678")
+	h := newTFHarness(t, f.api.client)
+
+	for id, want := range map[string]map[string]interface{}{
+		"400":      {"org_id": "1234", "name": "lan", "cidr": "10.2.0.0/24", "site_id": 1},
+		"5678/401": {"org_id": "5678", "name": "dmz", "cidr": "172.16.0.0/16", "tunnel_id": 7},
+	} {
+		r := h.resource("umbrella_internal_network")
+		r.importState("import "+id, id)
+		for _, name := range []string{"org_id", "name", "cidr"} {
+			if got := r.stateString(name); got != want[name] {
+				t.Errorf("import %s: %s = %q, want %q", id, name, got, want[name])
+			}
+		}
+		r.expectNoChanges("after import "+id, r.config(want))
+	}
+}
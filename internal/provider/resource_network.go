@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Resource: umbrella_network
+// -----------------------------------------------------------------------------
+
+type networkResource struct{ client *apiClient }
+
+type networkModel struct {
+	ID           types.String   `tfsdk:"id"`
+	OrgID        types.String   `tfsdk:"org_id"`
+	Name         types.String   `tfsdk:"name"`
+	IPAddress    types.String   `tfsdk:"ip_address"`
+	PrefixLength types.Int64    `tfsdk:"prefix_length"`
+	IsDynamic    types.Bool     `tfsdk:"is_dynamic"`
+	Status       types.String   `tfsdk:"status"`
+	IsVerified   types.Bool     `tfsdk:"is_verified"`
+	CreatedAt    types.String   `tfsdk:"created_at"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// network is the deployments API representation of a network identity.
+type network struct {
+	OriginID     int64  `json:"originId"`
+	Name         string `json:"name"`
+	IPAddress    string `json:"ipAddress"`
+	PrefixLength int64  `json:"prefixLength"`
+	IsDynamic    bool   `json:"isDynamic"`
+	Status       string `json:"status"`
+	IsVerified   bool   `json:"isVerified"`
+	CreatedAt    string `json:"createdAt"`
+}
+
+func NewNetworkResource() resource.Resource { return &networkResource{} }
+
+func (r *networkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "umbrella_network"
+}
+
+func (r *networkResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*apiClient)
+}
+
+func (r *networkResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Umbrella Network identity (egress IP range)",
+		Attributes: map[string]schema.Attribute{
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Network origin ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name":          schema.StringAttribute{Required: true, Description: "Network name"},
+			"ip_address":    schema.StringAttribute{Optional: true, Description: "Public egress IP address; omit for dynamic networks"},
+			"prefix_length": schema.Int64Attribute{Required: true, Description: "Prefix length of the egress range (e.g. 32 for a single address)"},
+			"is_dynamic": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the network's IP is dynamic and updated by the Umbrella dynamic IP updater (default: false)",
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("OPEN"),
+				Description: "Network status: OPEN or CLOSED (default: OPEN)",
+			},
+			"is_verified": schema.BoolAttribute{Computed: true, Description: "Whether Umbrella has verified ownership of the network"},
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation timestamp in ISO 8601 format",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+// ------------------ CRUD ------------------
+
+func (r *networkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan networkModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	var out network
+	if err := r.client.doJSON(ctx, http.MethodPost, fmt.Sprintf(networksPath, orgID), plan.payload(), &out); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(out.OriginID, 10))
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *networkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state networkModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
+
+	var out network
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(networksPath+"/%s", orgID, state.ID.ValueString()), nil, &out); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+	out.toModel(&state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *networkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state networkModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	var out network
+	if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(networksPath+"/%s", orgID, state.ID.ValueString()), plan.payload(), &out); err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *networkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state networkModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	err := r.client.doJSON(ctx, http.MethodDelete, fmt.Sprintf(networksPath+"/%s", orgID, state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+// ------------------ helpers ------------------
+
+func (m networkModel) payload() map[string]interface{} {
+	payload := map[string]interface{}{
+		"name":         m.Name.ValueString(),
+		"prefixLength": m.PrefixLength.ValueInt64(),
+		"isDynamic":    m.IsDynamic.ValueBool(),
+		"status":       m.Status.ValueString(),
+	}
+	if !m.IPAddress.IsNull() {
+		payload["ipAddress"] = m.IPAddress.ValueString()
+	}
+	return payload
+}
+
+func (n network) toModel(m *networkModel) {
+	m.Name = types.StringValue(n.Name)
+	m.IPAddress = stringValueOrNull(m.IPAddress, n.IPAddress)
+	m.PrefixLength = types.Int64Value(n.PrefixLength)
+	m.IsDynamic = types.BoolValue(n.IsDynamic)
+	m.Status = types.StringValue(n.Status)
+	m.IsVerified = types.BoolValue(n.IsVerified)
+	m.CreatedAt = types.StringValue(n.CreatedAt)
+}
@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Resource: umbrella_roaming_computer_tags
+// -----------------------------------------------------------------------------
+
+type roamingComputerTagsResource struct{ client *apiClient }
+
+type roamingComputerTagsModel struct {
+	ID       types.String   `tfsdk:"id"`
+	OrgID    types.String   `tfsdk:"org_id"`
+	DeviceID types.String   `tfsdk:"device_id"`
+	Tags     types.Set      `tfsdk:"tags"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewRoamingComputerTagsResource() resource.Resource { return &roamingComputerTagsResource{} }
+
+func (r *roamingComputerTagsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "umbrella_roaming_computer_tags"
+}
+
+func (r *roamingComputerTagsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*apiClient)
+}
+
+func (r *roamingComputerTagsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Assigns tags to one Umbrella roaming computer. Only the listed tags are managed; other tags on the device, e.g. from umbrella_tag_devices, are left alone.",
+		Attributes: map[string]schema.Attribute{
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Device ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"device_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "Device ID of the roaming computer, see the umbrella_roaming_computers data source",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"tags": schema.SetAttribute{Required: true, ElementType: types.StringType, Description: "Names of the tags to assign to the device"},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+// ------------------ CRUD ------------------
+
+func (r *roamingComputerTagsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan roamingComputerTagsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	tags := setToStringSlice(ctx, plan.Tags, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.updateRoamingComputerTags(ctx, orgID, plan.DeviceID.ValueString(), nil, tags); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+
+	plan.ID = plan.DeviceID
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *roamingComputerTagsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state roamingComputerTagsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
+
+	current, err := r.client.getRoamingComputerTags(ctx, orgID, state.DeviceID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+	// Only the managed tags are tracked; a managed tag removed outside
+	// Terraform drops out of state and is planned back.
+	tracked := setToStringSlice(ctx, state.Tags, &resp.Diagnostics)
+	managed := []string{}
+	for _, tag := range current {
+		if containsString(tracked, tag) {
+			managed = append(managed, tag)
+		}
+	}
+	state.Tags = stringSetValue(state.Tags, managed)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *roamingComputerTagsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state roamingComputerTagsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	tags := setToStringSlice(ctx, plan.Tags, &resp.Diagnostics)
+	_, removed := diffSlices(setToStringSlice(ctx, state.Tags, &resp.Diagnostics), tags)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.client.updateRoamingComputerTags(ctx, orgID, state.DeviceID.ValueString(), removed, tags); err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *roamingComputerTagsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state roamingComputerTagsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	tags := setToStringSlice(ctx, state.Tags, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.updateRoamingComputerTags(ctx, orgID, state.DeviceID.ValueString(), tags, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+// ------------------ helpers ------------------
+
+func (c *apiClient) getRoamingComputerTags(ctx context.Context, orgID, deviceID string) ([]string, error) {
+	var out roamingComputer
+	if err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf(roamingComputersPath+"/%s", orgID, deviceID), nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Tags, nil
+}
+
+// updateRoamingComputerTags removes and adds tags on a roaming computer. The
+// API replaces the device's full tag set, so the current tags are read first
+// and tags managed elsewhere are sent back unchanged.
+func (c *apiClient) updateRoamingComputerTags(ctx context.Context, orgID, deviceID string, remove, add []string) error {
+	current, err := c.getRoamingComputerTags(ctx, orgID, deviceID)
+	if err != nil {
+		return err
+	}
+	tags := []string{}
+	for _, tag := range current {
+		if !containsString(remove, tag) && !containsString(add, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	tags = append(tags, add...)
+	payload := map[string]interface{}{"tags": tags}
+	return c.doJSON(ctx, http.MethodPut, fmt.Sprintf(roamingComputersPath+"/%s/tags", orgID, deviceID), payload, nil)
+}
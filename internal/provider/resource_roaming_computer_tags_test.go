@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"testing"
+)
+
+// TestRoamingComputerTagsLeaveOtherTags checks that only the configured tags
+// are added and removed, so tags assigned elsewhere survive every apply.
+func TestRoamingComputerTagsLeaveOtherTags(t *testing.T) {
+	f := newFakeUmbrella(t)
+	f.seed(map[string]interface{}{"deviceId": "device-1", "originId": 300, "tags": []string{"vip"}}, roamingComputersPath+"/device-1", "1234")
+	r := newTFHarness(t, f.api.client).resource("umbrella_roaming_computer_tags")
+
+	expectTags := func(step string, want ...string) {
+		t.Helper()
+		got, err := f.api.client.getRoamingComputerTags(context.Background(), "1234", "device-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Strings(got)
+		sort.Strings(want)
+		if !stringSlicesEqual(got, want) {
+			t.Errorf("%s: device tags = %v, want %v", step, got, want)
+		}
+	}
+
+	config := r.config(map[string]interface{}{"device_id": "device-1", "tags": []string{"finance"}})
+	r.apply("create", config)
+	r.expectNoChanges("after create", config)
+	expectTags("create", "vip", "finance")
+
+	config = r.config(map[string]interface{}{"device_id": "device-1", "tags": []string{"ops"}})
+	r.apply("update", config)
+	expectTags("update", "vip", "ops")
+
+	f.seed(map[string]interface{}{"deviceId": "device-1", "originId": 300, "tags": []string{"vip", "ops", "laptops"}}, roamingComputersPath+"/device-1", "1234")
+	r.expectNoChanges("after tag added elsewhere", config)
+
+	r.destroy("destroy")
+	expectTags("destroy", "vip", "laptops")
+}
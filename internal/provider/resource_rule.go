@@ -2,12 +2,18 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -18,16 +24,19 @@ import (
 type ruleResource struct{ client *apiClient }
 
 type ruleModel struct {
-	ID               types.String `tfsdk:"id"`
-	RulesetID        types.String `tfsdk:"ruleset_id"`
-	Name             types.String `tfsdk:"name"`
-	Action           types.String `tfsdk:"action"`
-	Rank             types.Int64  `tfsdk:"rank"`
-	DestinationLists types.Set    `tfsdk:"destination_lists"`
-	Applications     types.Set    `tfsdk:"applications"`
-	Enabled          types.Bool   `tfsdk:"enabled"`
-	CreatedAt        types.String `tfsdk:"created_at"`
-	UpdatedAt        types.String `tfsdk:"updated_at"`
+	ID                     types.String   `tfsdk:"id"`
+	OrgID                  types.String   `tfsdk:"org_id"`
+	RulesetID              types.String   `tfsdk:"ruleset_id"`
+	Name                   types.String   `tfsdk:"name"`
+	Action                 types.String   `tfsdk:"action"`
+	Rank                   types.Int64    `tfsdk:"rank"`
+	DestinationLists       types.Set      `tfsdk:"destination_lists"`
+	Applications           types.Set      `tfsdk:"applications"`
+	ContentCategorySetting types.String   `tfsdk:"content_category_setting"`
+	Enabled                types.Bool     `tfsdk:"enabled"`
+	CreatedAt              types.String   `tfsdk:"created_at"`
+	UpdatedAt              types.String   `tfsdk:"updated_at"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewRuleResource() resource.Resource { return &ruleResource{} }
@@ -38,26 +47,47 @@ func (r *ruleResource) Metadata(_ context.Context, req resource.MetadataRequest,
 
 func (r *ruleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
-		resp.Diagnostics.AddError("Missing provider data", "internal: no client")
 		return
 	}
 	r.client = req.ProviderData.(*apiClient)
 }
 
-func (r *ruleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *ruleResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Umbrella SWG Rule within a Ruleset",
 		Attributes: map[string]schema.Attribute{
-			"id":                schema.StringAttribute{Computed: true, Description: "Rule ID"},
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Rule ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
 			"ruleset_id":        schema.StringAttribute{Required: true, Description: "ID of the ruleset this rule belongs to"},
 			"name":              schema.StringAttribute{Required: true, Description: "Rule name"},
 			"action":            schema.StringAttribute{Required: true, Description: "Rule action (ALLOW, BLOCK, DO_NOT_DECRYPT, etc.)"},
 			"rank":              schema.Int64Attribute{Required: true, Description: "Rule priority/rank (lower numbers have higher priority)"},
 			"destination_lists": schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "List of destination list names to apply this rule to"},
 			"applications":      schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "List of applications to apply this rule to"},
-			"enabled":           schema.BoolAttribute{Optional: true, Description: "Whether the rule is enabled"},
-			"created_at":        schema.StringAttribute{Computed: true, Description: "Creation timestamp"},
-			"updated_at":        schema.StringAttribute{Computed: true, Description: "Last update timestamp"},
+			"content_category_setting": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the content category setting whose categories this rule matches",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the rule is enabled (default: true)",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation timestamp",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"updated_at": schema.StringAttribute{Computed: true, Description: "Last update timestamp"},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
 		},
 	}
 }
@@ -69,6 +99,17 @@ func (r *ruleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
 	payload := map[string]interface{}{
 		"name":   plan.Name.ValueString(),
 		"action": plan.Action.ValueString(),
@@ -89,22 +130,17 @@ func (r *ruleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		payload["applications"] = []string{}
 	}
 
-	if !plan.Enabled.IsNull() {
-		payload["enabled"] = plan.Enabled.ValueBool()
-	}
-
-	body, _ := json.Marshal(payload)
-
-	apiResp, err := r.client.do(ctx, http.MethodPost, fmt.Sprintf(rulePath, r.client.orgID, plan.RulesetID.ValueString()), body)
-	if err != nil {
-		resp.Diagnostics.AddError("API error", err.Error())
-		return
+	if !plan.ContentCategorySetting.IsNull() {
+		id, err := r.client.contentCategorySettingID(ctx, orgID, plan.ContentCategorySetting.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("content_category_setting"), "Create failed", err.Error())
+			return
+		}
+		payload["contentCategorySettingId"] = id
 	}
-	defer apiResp.Body.Close()
 
-	if apiResp.StatusCode != http.StatusCreated && apiResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("Create failed", fmt.Sprintf("HTTP %s", apiResp.Status))
-		return
+	if !plan.Enabled.IsNull() {
+		payload["enabled"] = plan.Enabled.ValueBool()
 	}
 
 	var data struct {
@@ -114,27 +150,22 @@ func (r *ruleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		Rank             int64    `json:"rank"`
 		DestinationLists []string `json:"destinationLists"`
 		Applications     []string `json:"applications"`
-		Enabled          bool     `json:"enabled"`
+		Enabled          *bool    `json:"enabled"`
 		CreatedAt        string   `json:"createdAt"`
 		UpdatedAt        string   `json:"updatedAt"`
 	}
-	if err := json.NewDecoder(apiResp.Body).Decode(&data); err != nil {
-		resp.Diagnostics.AddError("decode", err.Error())
+	if err := r.client.doJSON(ctx, http.MethodPost, fmt.Sprintf(rulePath, orgID, plan.RulesetID.ValueString()), payload, &data); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
 		return
 	}
 
 	plan.ID = types.StringValue(data.ID)
-	plan.Enabled = types.BoolValue(data.Enabled)
+	plan.Enabled = boolValueOrPrior(plan.Enabled, data.Enabled)
 	plan.CreatedAt = types.StringValue(data.CreatedAt)
 	plan.UpdatedAt = types.StringValue(data.UpdatedAt)
 
-	// Set destination lists
-	destElems := stringSliceToAttrValues(data.DestinationLists)
-	plan.DestinationLists, _ = types.SetValue(types.StringType, destElems)
-
-	// Set applications
-	appElems := stringSliceToAttrValues(data.Applications)
-	plan.Applications, _ = types.SetValue(types.StringType, appElems)
+	plan.DestinationLists = stringSetValue(plan.DestinationLists, data.DestinationLists)
+	plan.Applications = stringSetValue(plan.Applications, data.Applications)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
@@ -146,53 +177,58 @@ func (r *ruleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	apiResp, err := r.client.do(ctx, http.MethodGet, fmt.Sprintf(rulePath+"/%s", r.client.orgID, state.RulesetID.ValueString(), state.ID.ValueString()), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("API error", err.Error())
-		return
-	}
-	defer apiResp.Body.Close()
-
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
-	if apiResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("Read failed", fmt.Sprintf("HTTP %s", apiResp.Status))
-		return
-	}
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
 
 	var rule struct {
-		ID               string   `json:"id"`
-		Name             string   `json:"name"`
-		Action           string   `json:"action"`
-		Rank             int64    `json:"rank"`
-		DestinationLists []string `json:"destinationLists"`
-		Applications     []string `json:"applications"`
-		Enabled          bool     `json:"enabled"`
-		CreatedAt        string   `json:"createdAt"`
-		UpdatedAt        string   `json:"updatedAt"`
+		ID                       string   `json:"id"`
+		Name                     string   `json:"name"`
+		Action                   string   `json:"action"`
+		Rank                     int64    `json:"rank"`
+		DestinationLists         []string `json:"destinationLists"`
+		Applications             []string `json:"applications"`
+		ContentCategorySettingID string   `json:"contentCategorySettingId"`
+		Enabled                  *bool    `json:"enabled"`
+		CreatedAt                string   `json:"createdAt"`
+		UpdatedAt                string   `json:"updatedAt"`
 	}
-	if err := json.NewDecoder(apiResp.Body).Decode(&rule); err != nil {
-		resp.Diagnostics.AddError("decode", err.Error())
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(rulePath+"/%s", orgID, state.RulesetID.ValueString(), state.ID.ValueString()), nil, &rule); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
 		return
 	}
 
 	state.Name = types.StringValue(rule.Name)
 	state.Action = types.StringValue(rule.Action)
 	state.Rank = types.Int64Value(rule.Rank)
-	state.Enabled = types.BoolValue(rule.Enabled)
+	state.Enabled = boolValueOrPrior(state.Enabled, rule.Enabled)
 	state.CreatedAt = types.StringValue(rule.CreatedAt)
 	state.UpdatedAt = types.StringValue(rule.UpdatedAt)
 
-	// Set destination lists
-	destElems := stringSliceToAttrValues(rule.DestinationLists)
-	state.DestinationLists, _ = types.SetValue(types.StringType, destElems)
+	state.DestinationLists = stringSetValue(state.DestinationLists, rule.DestinationLists)
+	state.Applications = stringSetValue(state.Applications, rule.Applications)
 
-	// Set applications
-	appElems := stringSliceToAttrValues(rule.Applications)
-	state.Applications, _ = types.SetValue(types.StringType, appElems)
+	settingName := ""
+	if rule.ContentCategorySettingID != "" {
+		name, err := r.client.contentCategorySettingName(ctx, orgID, rule.ContentCategorySettingID)
+		if err != nil && !isNotFound(err) {
+			resp.Diagnostics.AddError("Read failed", err.Error())
+			return
+		}
+		settingName = name
+	}
+	state.ContentCategorySetting = stringValueOrNull(state.ContentCategorySetting, settingName)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -205,6 +241,17 @@ func (r *ruleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
 	payload := map[string]interface{}{}
 	needsUpdate := false
 
@@ -241,30 +288,34 @@ func (r *ruleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		needsUpdate = true
 	}
 
-	if needsUpdate {
-		body, _ := json.Marshal(payload)
-
-		apiResp, err := r.client.do(ctx, http.MethodPut, fmt.Sprintf(rulePath+"/%s", r.client.orgID, state.RulesetID.ValueString(), state.ID.ValueString()), body)
-		if err != nil {
-			resp.Diagnostics.AddError("update rule", err.Error())
-			return
-		}
-		defer apiResp.Body.Close()
-
-		if apiResp.StatusCode != http.StatusOK {
-			resp.Diagnostics.AddError("Update failed", fmt.Sprintf("HTTP %s", apiResp.Status))
-			return
+	if !plan.ContentCategorySetting.Equal(state.ContentCategorySetting) {
+		id := ""
+		if !plan.ContentCategorySetting.IsNull() {
+			var err error
+			id, err = r.client.contentCategorySettingID(ctx, orgID, plan.ContentCategorySetting.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("content_category_setting"), "Update failed", err.Error())
+				return
+			}
 		}
+		payload["contentCategorySettingId"] = id
+		needsUpdate = true
+	}
 
+	if needsUpdate {
 		var data struct {
 			UpdatedAt string `json:"updatedAt"`
 		}
-		if err := json.NewDecoder(apiResp.Body).Decode(&data); err != nil {
-			resp.Diagnostics.AddError("decode", err.Error())
+		if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(rulePath+"/%s", orgID, state.RulesetID.ValueString(), state.ID.ValueString()), payload, &data); err != nil {
+			resp.Diagnostics.AddError("Update failed", err.Error())
 			return
 		}
 
 		plan.UpdatedAt = types.StringValue(data.UpdatedAt)
+	} else {
+		// Nothing Umbrella stores changed (e.g. only timeouts), so keep the
+		// computed values rather than leaving them unknown.
+		plan.UpdatedAt = state.UpdatedAt
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
@@ -277,14 +328,18 @@ func (r *ruleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	apiResp, err := r.client.do(ctx, http.MethodDelete, fmt.Sprintf(rulePath+"/%s", r.client.orgID, state.RulesetID.ValueString(), state.ID.ValueString()), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("delete", err.Error())
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	defer apiResp.Body.Close()
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
 
-	if apiResp.StatusCode != http.StatusNoContent && apiResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("Delete failed", fmt.Sprintf("HTTP %s", apiResp.Status))
+	err := r.client.doJSON(ctx, http.MethodDelete, fmt.Sprintf(rulePath+"/%s", orgID, state.RulesetID.ValueString(), state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
 	}
 }
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestRuleContentCategorySettingByName checks that rules name their content
+// category setting and the provider sends and reads back its ID.
+func TestRuleContentCategorySettingByName(t *testing.T) {
+	f := newFakeUmbrella(t)
+	f.seed(map[string]interface{}{"id": 300, "name": "Strict", "categories": []interface{}{200, 201}}, contentCategorySettingsPath+"/300", "1234")
+	f.seed(map[string]interface{}{"id": 301, "name": "Relaxed", "categories": []interface{}{201}}, contentCategorySettingsPath+"/301", "1234")
+	r := newTFHarness(t, f.api.client).resource("umbrella_rule")
+	attrs := map[string]interface{}{"ruleset_id": "10", "name": "block social", "action": "BLOCK", "rank": 1}
+
+	attrs["content_category_setting"] = "Strict"
+	r.apply("create", r.config(attrs))
+	r.expectNoChanges("after create", r.config(attrs))
+	if got := f.received(http.MethodPost, `/rules$`)[0].body.(map[string]interface{})["contentCategorySettingId"]; got != "300" {
+		t.Errorf("create: contentCategorySettingId = %v, want 300", got)
+	}
+
+	attrs["content_category_setting"] = "Relaxed"
+	r.apply("switch setting", r.config(attrs))
+	r.expectNoChanges("after switch setting", r.config(attrs))
+	if got := f.received(http.MethodPut, `/rules/[^/]+$`)[0].body.(map[string]interface{})["contentCategorySettingId"]; got != "301" {
+		t.Errorf("switch setting: contentCategorySettingId = %v, want 301", got)
+	}
+
+	attrs["content_category_setting"] = "Lax"
+	if msg := r.applyError("unknown setting", r.config(attrs)); !strings.Contains(msg, `"Lax" not found`) {
+		t.Errorf("unknown setting: got %q, want a not found error", msg)
+	}
+
+	delete(attrs, "content_category_setting")
+	r.apply("drop setting", r.config(attrs))
+	r.expectNoChanges("after drop setting", r.config(attrs))
+	r.destroy("destroy")
+}
@@ -1,13 +1,20 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -18,13 +25,43 @@ import (
 type rulesetResource struct{ client *apiClient }
 
 type rulesetModel struct {
-	ID                   types.String `tfsdk:"id"`
-	Name                 types.String `tfsdk:"name"`
-	Description          types.String `tfsdk:"description"`
-	SAMLEnabled          types.Bool   `tfsdk:"saml_enabled"`
-	SSLDecryptionEnabled types.Bool   `tfsdk:"ssl_decryption_enabled"`
-	CreatedAt            types.String `tfsdk:"created_at"`
-	UpdatedAt            types.String `tfsdk:"updated_at"`
+	ID                   types.String             `tfsdk:"id"`
+	OrgID                types.String             `tfsdk:"org_id"`
+	Name                 types.String             `tfsdk:"name"`
+	Description          types.String             `tfsdk:"description"`
+	SAMLEnabled          types.Bool               `tfsdk:"saml_enabled"`
+	SSLDecryptionEnabled types.Bool               `tfsdk:"ssl_decryption_enabled"`
+	Identities           *rulesetIdentitiesModel  `tfsdk:"identities"`
+	DefaultRule          *rulesetDefaultRuleModel `tfsdk:"default_rule"`
+	Settings             *rulesetSettingsModel    `tfsdk:"settings"`
+	CreatedAt            types.String             `tfsdk:"created_at"`
+	UpdatedAt            types.String             `tfsdk:"updated_at"`
+	Timeouts             timeouts.Value           `tfsdk:"timeouts"`
+}
+
+type rulesetIdentitiesModel struct {
+	Networks         types.Set `tfsdk:"networks"`
+	Tunnels          types.Set `tfsdk:"tunnels"`
+	Sites            types.Set `tfsdk:"sites"`
+	RoamingComputers types.Set `tfsdk:"roaming_computers"`
+	Groups           types.Set `tfsdk:"groups"`
+	Tags             types.Set `tfsdk:"tags"`
+}
+
+type rulesetDefaultRuleModel struct {
+	Action         types.String `tfsdk:"action"`
+	LoggingEnabled types.Bool   `tfsdk:"logging_enabled"`
+}
+
+type rulesetSettingsModel struct {
+	SelectiveDecryptionListID types.String `tfsdk:"selective_decryption_list_id"`
+	FileAnalysisEnabled       types.Bool   `tfsdk:"file_analysis_enabled"`
+	FileTypeControlEnabled    types.Bool   `tfsdk:"file_type_control_enabled"`
+	BlockedFileTypes          types.Set    `tfsdk:"blocked_file_types"`
+	SecuritySettingID         types.String `tfsdk:"security_setting_id"`
+	ContentCategorySettingID  types.String `tfsdk:"content_category_setting_id"`
+	BlockPageID               types.String `tfsdk:"block_page_id"`
+	WarnPageID                types.String `tfsdk:"warn_page_id"`
 }
 
 func NewRulesetResource() resource.Resource { return &rulesetResource{} }
@@ -35,27 +72,73 @@ func (r *rulesetResource) Metadata(_ context.Context, req resource.MetadataReque
 
 func (r *rulesetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
-		resp.Diagnostics.AddError("Missing provider data", "internal: no client")
 		return
 	}
 	r.client = req.ProviderData.(*apiClient)
 }
 
-func (r *rulesetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *rulesetResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Umbrella SWG Ruleset Configuration",
 		Attributes: map[string]schema.Attribute{
-			"id":                     schema.StringAttribute{Computed: true, Description: "Ruleset ID"},
-			"name":                   schema.StringAttribute{Required: true, Description: "Ruleset name"},
-			"description":            schema.StringAttribute{Optional: true, Description: "Ruleset description"},
-			"saml_enabled":           schema.BoolAttribute{Optional: true, Description: "Enable SAML authentication for this ruleset"},
-			"ssl_decryption_enabled": schema.BoolAttribute{Optional: true, Description: "Enable SSL decryption for this ruleset"},
-			"created_at":             schema.StringAttribute{Computed: true, Description: "Creation timestamp"},
-			"updated_at":             schema.StringAttribute{Computed: true, Description: "Last update timestamp"},
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Ruleset ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name":        schema.StringAttribute{Required: true, Description: "Ruleset name"},
+			"description": schema.StringAttribute{Optional: true, Description: "Ruleset description"},
+			"saml_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Enable SAML authentication for this ruleset (default: false)",
+			},
+			"ssl_decryption_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Enable SSL decryption for this ruleset (default: false)",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation timestamp",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"updated_at": schema.StringAttribute{Computed: true, Description: "Last update timestamp"},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts":   timeouts.BlockAll(ctx),
+			"identities": identitiesBlock("Identities the ruleset applies to. A ruleset without identities is never matched."),
+			"default_rule": schema.SingleNestedBlock{
+				Description: "Built-in default rule evaluated when no other rule in the ruleset matches",
+				Attributes: map[string]schema.Attribute{
+					"action":          schema.StringAttribute{Optional: true, Description: "Default action (ALLOW | BLOCK)"},
+					"logging_enabled": schema.BoolAttribute{Optional: true, Description: "Log requests matched by the default rule"},
+				},
+			},
+			"settings": schema.SingleNestedBlock{
+				Description: "Ruleset settings. Only settings set here are managed; the rest keep their Umbrella values.",
+				Attributes: map[string]schema.Attribute{
+					"selective_decryption_list_id": schema.StringAttribute{Optional: true, Description: "Selective decryption (do-not-decrypt) list ID"},
+					"file_analysis_enabled":        schema.BoolAttribute{Optional: true, Description: "Enable file analysis (AMP) for downloads"},
+					"file_type_control_enabled":    schema.BoolAttribute{Optional: true, Description: "Enable file type control"},
+					"blocked_file_types":           schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "File extensions blocked when file type control is enabled"},
+					"security_setting_id":          schema.StringAttribute{Optional: true, Description: "Security setting ID applied by the ruleset"},
+					"content_category_setting_id":  schema.StringAttribute{Optional: true, Description: "Content category setting ID applied by the ruleset"},
+					"block_page_id":                schema.StringAttribute{Optional: true, Description: "Block page appearance ID shown to blocked users"},
+					"warn_page_id":                 schema.StringAttribute{Optional: true, Description: "Warn page appearance ID shown for warned destinations"},
+				},
+			},
 		},
 	}
 }
 
+func (r *rulesetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateWithOrgID(ctx, req, resp)
+}
+
 func (r *rulesetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan rulesetModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -63,6 +146,17 @@ func (r *rulesetResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
 	payload := map[string]interface{}{
 		"name": plan.Name.ValueString(),
 	}
@@ -76,39 +170,43 @@ func (r *rulesetResource) Create(ctx context.Context, req resource.CreateRequest
 	if !plan.SSLDecryptionEnabled.IsNull() {
 		payload["sslDecryptionEnabled"] = plan.SSLDecryptionEnabled.ValueBool()
 	}
-
-	body, _ := json.Marshal(payload)
-
-	apiResp, err := r.client.do(ctx, http.MethodPost, fmt.Sprintf(rulesetPath, r.client.orgID), body)
-	if err != nil {
-		resp.Diagnostics.AddError("API error", err.Error())
-		return
+	if plan.Identities != nil {
+		payload["identities"] = plan.Identities.toAPI(ctx, &resp.Diagnostics)
 	}
-	defer apiResp.Body.Close()
-
-	if apiResp.StatusCode != http.StatusCreated && apiResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("Create failed", fmt.Sprintf("HTTP %s", apiResp.Status))
+	if plan.DefaultRule != nil {
+		payload["defaultRule"] = plan.DefaultRule.toAPI()
+	}
+	if plan.Settings != nil {
+		payload["settings"] = changedRulesetSettings(plan.Settings.toAPI(ctx, &resp.Diagnostics), nil)
+	}
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	var data struct {
-		ID                   string `json:"id"`
-		Name                 string `json:"name"`
-		Description          string `json:"description"`
-		SAMLEnabled          bool   `json:"samlEnabled"`
-		SSLDecryptionEnabled bool   `json:"sslDecryptionEnabled"`
-		CreatedAt            string `json:"createdAt"`
-		UpdatedAt            string `json:"updatedAt"`
-	}
-	if err := json.NewDecoder(apiResp.Body).Decode(&data); err != nil {
-		resp.Diagnostics.AddError("decode", err.Error())
+		ID                   string              `json:"id"`
+		Name                 string              `json:"name"`
+		Description          string              `json:"description"`
+		SAMLEnabled          *bool               `json:"samlEnabled"`
+		SSLDecryptionEnabled *bool               `json:"sslDecryptionEnabled"`
+		Identities           rulesetIdentities   `json:"identities"`
+		DefaultRule          *rulesetDefaultRule `json:"defaultRule"`
+		Settings             []rulesetSetting    `json:"settings"`
+		CreatedAt            string              `json:"createdAt"`
+		UpdatedAt            string              `json:"updatedAt"`
+	}
+	if err := r.client.doJSON(ctx, http.MethodPost, fmt.Sprintf(rulesetPath, orgID), payload, &data); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
 		return
 	}
 
 	plan.ID = types.StringValue(data.ID)
-	plan.Description = types.StringValue(data.Description)
-	plan.SAMLEnabled = types.BoolValue(data.SAMLEnabled)
-	plan.SSLDecryptionEnabled = types.BoolValue(data.SSLDecryptionEnabled)
+	plan.Description = stringValueOrNull(plan.Description, data.Description)
+	plan.SAMLEnabled = boolValueOrPrior(plan.SAMLEnabled, data.SAMLEnabled)
+	plan.SSLDecryptionEnabled = boolValueOrPrior(plan.SSLDecryptionEnabled, data.SSLDecryptionEnabled)
+	plan.Identities = data.Identities.toModel(plan.Identities)
+	plan.DefaultRule = data.DefaultRule.toModel(plan.DefaultRule)
+	plan.Settings = rulesetSettingsToModel(plan.Settings, data.Settings)
 	plan.CreatedAt = types.StringValue(data.CreatedAt)
 	plan.UpdatedAt = types.StringValue(data.UpdatedAt)
 
@@ -122,41 +220,45 @@ func (r *rulesetResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	apiResp, err := r.client.do(ctx, http.MethodGet, fmt.Sprintf(rulesetPath+"/%s", r.client.orgID, state.ID.ValueString()), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("API error", err.Error())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	defer apiResp.Body.Close()
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-
-	if apiResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("Read failed", fmt.Sprintf("HTTP %s", apiResp.Status))
-		return
-	}
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
 
 	var ruleset struct {
-		ID                   string `json:"id"`
-		Name                 string `json:"name"`
-		Description          string `json:"description"`
-		SAMLEnabled          bool   `json:"samlEnabled"`
-		SSLDecryptionEnabled bool   `json:"sslDecryptionEnabled"`
-		CreatedAt            string `json:"createdAt"`
-		UpdatedAt            string `json:"updatedAt"`
-	}
-	if err := json.NewDecoder(apiResp.Body).Decode(&ruleset); err != nil {
-		resp.Diagnostics.AddError("decode", err.Error())
+		ID                   string              `json:"id"`
+		Name                 string              `json:"name"`
+		Description          string              `json:"description"`
+		SAMLEnabled          *bool               `json:"samlEnabled"`
+		SSLDecryptionEnabled *bool               `json:"sslDecryptionEnabled"`
+		Identities           rulesetIdentities   `json:"identities"`
+		DefaultRule          *rulesetDefaultRule `json:"defaultRule"`
+		Settings             []rulesetSetting    `json:"settings"`
+		CreatedAt            string              `json:"createdAt"`
+		UpdatedAt            string              `json:"updatedAt"`
+	}
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(rulesetPath+"/%s", orgID, state.ID.ValueString()), nil, &ruleset); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
 		return
 	}
 
 	state.Name = types.StringValue(ruleset.Name)
-	state.Description = types.StringValue(ruleset.Description)
-	state.SAMLEnabled = types.BoolValue(ruleset.SAMLEnabled)
-	state.SSLDecryptionEnabled = types.BoolValue(ruleset.SSLDecryptionEnabled)
+	state.Description = stringValueOrNull(state.Description, ruleset.Description)
+	state.SAMLEnabled = boolValueOrPrior(state.SAMLEnabled, ruleset.SAMLEnabled)
+	state.SSLDecryptionEnabled = boolValueOrPrior(state.SSLDecryptionEnabled, ruleset.SSLDecryptionEnabled)
+	state.Identities = ruleset.Identities.toModel(state.Identities)
+	state.DefaultRule = ruleset.DefaultRule.toModel(state.DefaultRule)
+	state.Settings = rulesetSettingsToModel(state.Settings, ruleset.Settings)
 	state.CreatedAt = types.StringValue(ruleset.CreatedAt)
 	state.UpdatedAt = types.StringValue(ruleset.UpdatedAt)
 
@@ -171,6 +273,17 @@ func (r *rulesetResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
 	payload := map[string]interface{}{}
 	needsUpdate := false
 
@@ -191,30 +304,46 @@ func (r *rulesetResource) Update(ctx context.Context, req resource.UpdateRequest
 		needsUpdate = true
 	}
 
-	if needsUpdate {
-		body, _ := json.Marshal(payload)
-
-		apiResp, err := r.client.do(ctx, http.MethodPatch, fmt.Sprintf(rulesetPath+"/%s", r.client.orgID, state.ID.ValueString()), body)
-		if err != nil {
-			resp.Diagnostics.AddError("update ruleset", err.Error())
-			return
-		}
-		defer apiResp.Body.Close()
-
-		if apiResp.StatusCode != http.StatusOK {
-			resp.Diagnostics.AddError("Update failed", fmt.Sprintf("HTTP %s", apiResp.Status))
-			return
+	// Identities are sent as a whole object; removing the block unbinds the
+	// ruleset. The default rule always exists, so removing its block only
+	// stops Terraform managing it.
+	planIdentities := plan.Identities.toAPI(ctx, &resp.Diagnostics)
+	stateIdentities := state.Identities.toAPI(ctx, &resp.Diagnostics)
+	if !planIdentities.equal(stateIdentities) {
+		payload["identities"] = planIdentities
+		needsUpdate = true
+	}
+	if plan.DefaultRule != nil && (state.DefaultRule == nil || *plan.DefaultRule.toAPI() != *state.DefaultRule.toAPI()) {
+		payload["defaultRule"] = plan.DefaultRule.toAPI()
+		needsUpdate = true
+	}
+	// Only settings whose value changed are sent in the PATCH.
+	if plan.Settings != nil {
+		changed := changedRulesetSettings(plan.Settings.toAPI(ctx, &resp.Diagnostics), state.Settings.toAPI(ctx, &resp.Diagnostics))
+		if len(changed) > 0 {
+			payload["settings"] = changed
+			needsUpdate = true
 		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	if needsUpdate {
 		var data struct {
 			UpdatedAt string `json:"updatedAt"`
 		}
-		if err := json.NewDecoder(apiResp.Body).Decode(&data); err != nil {
-			resp.Diagnostics.AddError("decode", err.Error())
+		if err := r.client.doJSON(ctx, http.MethodPatch, fmt.Sprintf(rulesetPath+"/%s", orgID, state.ID.ValueString()), payload, &data); err != nil {
+			resp.Diagnostics.AddError("Update failed", err.Error())
 			return
 		}
 
 		plan.UpdatedAt = types.StringValue(data.UpdatedAt)
+	} else {
+		// Nothing Umbrella stores changed (e.g. only the default_rule block
+		// was dropped or timeouts changed), so keep the computed values
+		// rather than leaving them unknown.
+		plan.UpdatedAt = state.UpdatedAt
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
@@ -227,14 +356,241 @@ func (r *rulesetResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	apiResp, err := r.client.do(ctx, http.MethodDelete, fmt.Sprintf(rulesetPath+"/%s", r.client.orgID, state.ID.ValueString()), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("delete", err.Error())
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	defer apiResp.Body.Close()
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	err := r.client.doJSON(ctx, http.MethodDelete, fmt.Sprintf(rulesetPath+"/%s", orgID, state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+// ------------------ helpers ------------------
+
+// identitiesBlock is the identities block shared by rulesets and DNS policies.
+func identitiesBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"networks":          schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "Network origin IDs"},
+			"tunnels":           schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "Tunnel IDs"},
+			"sites":             schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "Site origin IDs"},
+			"roaming_computers": schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "Roaming computer origin IDs"},
+			"groups":            schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "Directory group IDs"},
+			"tags":              schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "Roaming computer tags"},
+		},
+	}
+}
+
+// rulesetIdentities is the wire format of the identities a ruleset or DNS
+// policy is bound to.
+type rulesetIdentities struct {
+	Networks         []string `json:"networks"`
+	Tunnels          []string `json:"tunnels"`
+	Sites            []string `json:"sites"`
+	RoamingComputers []string `json:"roamingComputers"`
+	Groups           []string `json:"groups"`
+	Tags             []string `json:"tags"`
+}
+
+// rulesetDefaultRule is the wire format of the ruleset's built-in default rule.
+type rulesetDefaultRule struct {
+	Action         string `json:"action,omitempty"`
+	LoggingEnabled bool   `json:"loggingEnabled"`
+}
+
+func (m *rulesetIdentitiesModel) toAPI(ctx context.Context, diags *diag.Diagnostics) rulesetIdentities {
+	if m == nil {
+		return rulesetIdentities{Networks: []string{}, Tunnels: []string{}, Sites: []string{}, RoamingComputers: []string{}, Groups: []string{}, Tags: []string{}}
+	}
+	return rulesetIdentities{
+		Networks:         setToStringSlice(ctx, m.Networks, diags),
+		Tunnels:          setToStringSlice(ctx, m.Tunnels, diags),
+		Sites:            setToStringSlice(ctx, m.Sites, diags),
+		RoamingComputers: setToStringSlice(ctx, m.RoamingComputers, diags),
+		Groups:           setToStringSlice(ctx, m.Groups, diags),
+		Tags:             setToStringSlice(ctx, m.Tags, diags),
+	}
+}
+
+func (i rulesetIdentities) equal(o rulesetIdentities) bool {
+	return stringSlicesEqual(i.Networks, o.Networks) &&
+		stringSlicesEqual(i.Tunnels, o.Tunnels) &&
+		stringSlicesEqual(i.Sites, o.Sites) &&
+		stringSlicesEqual(i.RoamingComputers, o.RoamingComputers) &&
+		stringSlicesEqual(i.Groups, o.Groups) &&
+		stringSlicesEqual(i.Tags, o.Tags)
+}
+
+// toModel converts the API identities back into the block. Bindings are only
+// tracked once the block is configured, so identities bound outside Terraform
+// are not unbound by a ruleset that never declared any.
+func (i rulesetIdentities) toModel(prior *rulesetIdentitiesModel) *rulesetIdentitiesModel {
+	if prior == nil {
+		return nil
+	}
+	return &rulesetIdentitiesModel{
+		Networks:         stringSetValue(prior.Networks, i.Networks),
+		Tunnels:          stringSetValue(prior.Tunnels, i.Tunnels),
+		Sites:            stringSetValue(prior.Sites, i.Sites),
+		RoamingComputers: stringSetValue(prior.RoamingComputers, i.RoamingComputers),
+		Groups:           stringSetValue(prior.Groups, i.Groups),
+		Tags:             stringSetValue(prior.Tags, i.Tags),
+	}
+}
+
+func (m *rulesetDefaultRuleModel) toAPI() *rulesetDefaultRule {
+	if m == nil {
+		return nil
+	}
+	return &rulesetDefaultRule{Action: m.Action.ValueString(), LoggingEnabled: m.LoggingEnabled.ValueBool()}
+}
+
+// toModel converts the API default rule back into the block. Umbrella always
+// has a default rule, so it is only tracked once the block is configured.
+func (d *rulesetDefaultRule) toModel(prior *rulesetDefaultRuleModel) *rulesetDefaultRuleModel {
+	if prior == nil || d == nil {
+		return prior
+	}
+	out := &rulesetDefaultRuleModel{Action: types.StringValue(d.Action), LoggingEnabled: types.BoolValue(d.LoggingEnabled)}
+	if prior.Action.IsNull() && d.Action == "" {
+		out.Action = types.StringNull()
+	}
+	if prior.LoggingEnabled.IsNull() && !d.LoggingEnabled {
+		out.LoggingEnabled = types.BoolNull()
+	}
+	return out
+}
+
+// Umbrella setting names backing the attributes of the settings block.
+const (
+	settingSelectiveDecryptionList = "umbrella.selectiveDecryptionListId"
+	settingFileAnalysis            = "umbrella.fileAnalysisEnabled"
+	settingFileTypeControl         = "umbrella.fileTypeControlEnabled"
+	settingBlockedFileTypes        = "umbrella.blockedFileTypes"
+	settingSecuritySetting         = "umbrella.securitySettingId"
+	settingContentCategorySetting  = "umbrella.contentCategorySettingId"
+	settingBlockPage               = "umbrella.blockPageId"
+	settingWarnPage                = "umbrella.warnPageId"
+)
+
+// rulesetSetting is a single name/value entry of a ruleset's settings list.
+type rulesetSetting struct {
+	SettingName  string          `json:"settingName"`
+	SettingValue json.RawMessage `json:"settingValue"`
+}
+
+// toAPI encodes every configured setting, keyed by setting name. Null
+// attributes are left out so they stay unmanaged.
+func (m *rulesetSettingsModel) toAPI(ctx context.Context, diags *diag.Diagnostics) map[string]json.RawMessage {
+	out := map[string]json.RawMessage{}
+	if m == nil {
+		return out
+	}
+	put := func(name string, v interface{}) {
+		b, _ := json.Marshal(v)
+		out[name] = b
+	}
+	if !m.SelectiveDecryptionListID.IsNull() {
+		put(settingSelectiveDecryptionList, m.SelectiveDecryptionListID.ValueString())
+	}
+	if !m.FileAnalysisEnabled.IsNull() {
+		put(settingFileAnalysis, m.FileAnalysisEnabled.ValueBool())
+	}
+	if !m.FileTypeControlEnabled.IsNull() {
+		put(settingFileTypeControl, m.FileTypeControlEnabled.ValueBool())
+	}
+	if !m.BlockedFileTypes.IsNull() {
+		fileTypes := setToStringSlice(ctx, m.BlockedFileTypes, diags)
+		sort.Strings(fileTypes)
+		put(settingBlockedFileTypes, fileTypes)
+	}
+	if !m.SecuritySettingID.IsNull() {
+		put(settingSecuritySetting, m.SecuritySettingID.ValueString())
+	}
+	if !m.ContentCategorySettingID.IsNull() {
+		put(settingContentCategorySetting, m.ContentCategorySettingID.ValueString())
+	}
+	if !m.BlockPageID.IsNull() {
+		put(settingBlockPage, m.BlockPageID.ValueString())
+	}
+	if !m.WarnPageID.IsNull() {
+		put(settingWarnPage, m.WarnPageID.ValueString())
+	}
+	return out
+}
+
+// changedRulesetSettings returns the settings in want whose encoded value
+// differs from have, sorted by name so requests are deterministic.
+func changedRulesetSettings(want, have map[string]json.RawMessage) []rulesetSetting {
+	out := []rulesetSetting{}
+	for name, v := range want {
+		if old, ok := have[name]; ok && bytes.Equal(old, v) {
+			continue
+		}
+		out = append(out, rulesetSetting{SettingName: name, SettingValue: v})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].SettingName < out[j].SettingName })
+	return out
+}
+
+// rulesetSettingsToModel refreshes the managed (non-null) settings from the
+// API so out-of-band changes show up as drift.
+func rulesetSettingsToModel(prior *rulesetSettingsModel, settings []rulesetSetting) *rulesetSettingsModel {
+	if prior == nil {
+		return nil
+	}
+	byName := map[string]json.RawMessage{}
+	for _, st := range settings {
+		byName[st.SettingName] = st.SettingValue
+	}
+	out := *prior
+	if v, ok := byName[settingSelectiveDecryptionList]; ok && !prior.SelectiveDecryptionListID.IsNull() {
+		out.SelectiveDecryptionListID = types.StringValue(settingString(v))
+	}
+	if v, ok := byName[settingFileAnalysis]; ok && !prior.FileAnalysisEnabled.IsNull() {
+		var b bool
+		_ = json.Unmarshal(v, &b)
+		out.FileAnalysisEnabled = types.BoolValue(b)
+	}
+	if v, ok := byName[settingFileTypeControl]; ok && !prior.FileTypeControlEnabled.IsNull() {
+		var b bool
+		_ = json.Unmarshal(v, &b)
+		out.FileTypeControlEnabled = types.BoolValue(b)
+	}
+	if v, ok := byName[settingBlockedFileTypes]; ok && !prior.BlockedFileTypes.IsNull() {
+		var fileTypes []string
+		_ = json.Unmarshal(v, &fileTypes)
+		out.BlockedFileTypes = stringSetValue(prior.BlockedFileTypes, fileTypes)
+	}
+	if v, ok := byName[settingSecuritySetting]; ok && !prior.SecuritySettingID.IsNull() {
+		out.SecuritySettingID = types.StringValue(settingString(v))
+	}
+	if v, ok := byName[settingContentCategorySetting]; ok && !prior.ContentCategorySettingID.IsNull() {
+		out.ContentCategorySettingID = types.StringValue(settingString(v))
+	}
+	if v, ok := byName[settingBlockPage]; ok && !prior.BlockPageID.IsNull() {
+		out.BlockPageID = types.StringValue(settingString(v))
+	}
+	if v, ok := byName[settingWarnPage]; ok && !prior.WarnPageID.IsNull() {
+		out.WarnPageID = types.StringValue(settingString(v))
+	}
+	return &out
+}
 
-	if apiResp.StatusCode != http.StatusNoContent && apiResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("Delete failed", fmt.Sprintf("HTTP %s", apiResp.Status))
+// settingString decodes an ID-valued setting, which Umbrella may return as
+// either a JSON string or a number.
+func settingString(v json.RawMessage) string {
+	var str string
+	if err := json.Unmarshal(v, &str); err == nil {
+		return str
 	}
+	return string(bytes.TrimSpace(v))
 }
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// rulesetConfig is a ruleset with every top-level attribute set, plus blocks.
+func rulesetConfig(blocks map[string]interface{}) map[string]interface{} {
+	attrs := map[string]interface{}{
+		"name": "web", "description": "web policy", "saml_enabled": false, "ssl_decryption_enabled": false,
+	}
+	for k, v := range blocks {
+		attrs[k] = v
+	}
+	return attrs
+}
+
+// fakeStrings converts a JSON array stored by the fake into strings.
+func fakeStrings(v interface{}) []string {
+	out := []string{}
+	items, _ := v.([]interface{})
+	for _, item := range items {
+		out = append(out, item.(string))
+	}
+	return out
+}
+
+func TestRulesetBindsAndUnbindsIdentities(t *testing.T) {
+	f := newFakeUmbrella(t)
+	r := newTFHarness(t, f.api.client).resource("umbrella_ruleset")
+	identities := func() map[string]interface{} {
+		return f.object(rulesetPath+"/%s", "1234", r.stateString("id"))["identities"].(map[string]interface{})
+	}
+
+	config := r.config(rulesetConfig(map[string]interface{}{
+		"identities": map[string]interface{}{"tunnels": []string{"11"}, "networks": []string{"21", "22"}},
+	}))
+	r.apply("bind", config)
+	r.expectNoChanges("after bind", config)
+	if got := fakeStrings(identities()["networks"]); !stringSlicesEqual(got, []string{"21", "22"}) {
+		t.Errorf("bound networks = %v, want [21 22]", got)
+	}
+
+	config = r.config(rulesetConfig(map[string]interface{}{
+		"identities": map[string]interface{}{"tunnels": []string{"12"}},
+	}))
+	r.apply("rebind", config)
+	r.expectNoChanges("after rebind", config)
+	if got := fakeStrings(identities()["tunnels"]); !reflect.DeepEqual(got, []string{"12"}) {
+		t.Errorf("bound tunnels = %v, want [12]", got)
+	}
+	if got := fakeStrings(identities()["networks"]); len(got) != 0 {
+		t.Errorf("networks still bound: %v", got)
+	}
+
+	config = r.config(rulesetConfig(nil))
+	r.apply("unbind", config)
+	r.expectNoChanges("after unbind", config)
+	for kind, ids := range identities() {
+		if got := fakeStrings(ids); len(got) != 0 {
+			t.Errorf("%s still bound: %v", kind, got)
+		}
+	}
+	r.destroy("destroy")
+}
+
+// TestRulesetLeavesUnmanagedIdentities covers bindings made outside Terraform
+// on a ruleset without an identities block: they must not show up as drift
+// or be removed by the next apply.
+func TestRulesetLeavesUnmanagedIdentities(t *testing.T) {
+	f := newFakeUmbrella(t)
+	r := newTFHarness(t, f.api.client).resource("umbrella_ruleset")
+
+	config := r.config(rulesetConfig(nil))
+	r.apply("create", config)
+	obj := f.object(rulesetPath+"/%s", "1234", r.stateString("id"))
+	f.mu.Lock()
+	obj["identities"] = map[string]interface{}{"tunnels": []interface{}{"99"}}
+	f.mu.Unlock()
+
+	r.expectNoChanges("after binding outside Terraform", config)
+	attrs := rulesetConfig(nil)
+	attrs["description"] = "renamed"
+	r.apply("update", r.config(attrs))
+	if got := fakeStrings(f.object(rulesetPath+"/%s", "1234", r.stateString("id"))["identities"].(map[string]interface{})["tunnels"]); !reflect.DeepEqual(got, []string{"99"}) {
+		t.Errorf("tunnels bound outside Terraform = %v after update, want [99]", got)
+	}
+	r.destroy("destroy")
+}
+
+func TestRulesetDefaultRule(t *testing.T) {
+	f := newFakeUmbrella(t)
+	r := newTFHarness(t, f.api.client).resource("umbrella_ruleset")
+	defaultRule := func() map[string]interface{} {
+		return f.object(rulesetPath+"/%s", "1234", r.stateString("id"))["defaultRule"].(map[string]interface{})
+	}
+
+	config := r.config(rulesetConfig(map[string]interface{}{
+		"default_rule": map[string]interface{}{"action": "BLOCK", "logging_enabled": true},
+	}))
+	r.apply("create", config)
+	r.expectNoChanges("after create", config)
+	if got := defaultRule(); got["action"] != "BLOCK" || got["loggingEnabled"] != true {
+		t.Errorf("default rule = %v, want BLOCK with logging", got)
+	}
+
+	config = r.config(rulesetConfig(map[string]interface{}{
+		"default_rule": map[string]interface{}{"action": "ALLOW"},
+	}))
+	r.apply("update", config)
+	r.expectNoChanges("after update", config)
+	if got := defaultRule(); got["action"] != "ALLOW" || got["loggingEnabled"] != false {
+		t.Errorf("default rule = %v, want ALLOW without logging", got)
+	}
+
+	rule := defaultRule()
+	f.mu.Lock()
+	rule["action"] = "BLOCK"
+	f.mu.Unlock()
+	if paths := r.changes("after change outside Terraform", config); len(paths) == 0 {
+		t.Error("default rule changed outside Terraform, but no change was planned")
+	}
+	r.apply("revert", config)
+	if got := defaultRule(); got["action"] != "ALLOW" {
+		t.Errorf("default rule action = %v after revert, want ALLOW", got["action"])
+	}
+
+	// Dropping the block stops managing the rule without changing it.
+	config = r.config(rulesetConfig(nil))
+	r.apply("unmanage", config)
+	r.expectNoChanges("after unmanage", config)
+	if got := defaultRule(); got["action"] != "ALLOW" {
+		t.Errorf("default rule action = %v after removing the block, want ALLOW", got["action"])
+	}
+	r.destroy("destroy")
+}
+
+// fakeSettings returns a stored ruleset's settings by name.
+func fakeSettings(f *fakeUmbrella, id string) map[string]interface{} {
+	out := map[string]interface{}{}
+	items, _ := f.object(rulesetPath+"/%s", "1234", id)["settings"].([]interface{})
+	for _, item := range items {
+		st := item.(map[string]interface{})
+		out[st["settingName"].(string)] = st["settingValue"]
+	}
+	return out
+}
+
+func TestRulesetSettings(t *testing.T) {
+	f := newFakeUmbrella(t)
+	r := newTFHarness(t, f.api.client).resource("umbrella_ruleset")
+
+	config := r.config(rulesetConfig(map[string]interface{}{
+		"settings": map[string]interface{}{"file_analysis_enabled": true, "block_page_id": "7"},
+	}))
+	r.apply("create", config)
+	r.expectNoChanges("after create", config)
+	id := r.stateString("id")
+	settings := fakeSettings(f, id)
+	if settings[settingFileAnalysis] != true || settings[settingBlockPage] != "7" {
+		t.Errorf("settings after create = %v", settings)
+	}
+
+	config = r.config(rulesetConfig(map[string]interface{}{
+		"settings": map[string]interface{}{"file_analysis_enabled": true, "block_page_id": "8"},
+	}))
+	r.apply("update", config)
+	r.expectNoChanges("after update", config)
+	patches := f.received(http.MethodPatch, "^"+pathPattern(rulesetPath)+"/"+id+"$")
+	if len(patches) != 1 {
+		t.Fatalf("%d PATCH requests, want 1", len(patches))
+	}
+	sent := patches[0].body.(map[string]interface{})["settings"].([]interface{})
+	if len(sent) != 1 || sent[0].(map[string]interface{})["settingName"] != settingBlockPage {
+		t.Errorf("PATCH sent settings %v, want only %s", sent, settingBlockPage)
+	}
+
+	// Settings left out of the block are not managed.
+	f.mu.Lock()
+	obj := f.objects[fmt.Sprintf(rulesetPath+"/%s", "1234", id)]
+	obj["settings"] = append(obj["settings"].([]interface{}), map[string]interface{}{"settingName": settingFileTypeControl, "settingValue": true})
+	f.mu.Unlock()
+	r.expectNoChanges("after unmanaged setting changed", config)
+
+	f.mu.Lock()
+	for _, item := range obj["settings"].([]interface{}) {
+		if st := item.(map[string]interface{}); st["settingName"] == settingFileAnalysis {
+			st["settingValue"] = false
+		}
+	}
+	f.mu.Unlock()
+	if paths := r.changes("after managed setting changed", config); len(paths) == 0 {
+		t.Error("file analysis was turned off outside Terraform, but no change was planned")
+	}
+	r.destroy("destroy")
+}
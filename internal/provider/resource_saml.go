@@ -2,12 +2,15 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -18,10 +21,12 @@ import (
 type samlResource struct{ client *apiClient }
 
 type samlModel struct {
-	ID          types.String `tfsdk:"id"`
-	MetadataURL types.String `tfsdk:"metadata_url"`
-	AuthType    types.String `tfsdk:"auth_type"`
-	Enabled     types.Bool   `tfsdk:"enabled"`
+	ID          types.String   `tfsdk:"id"`
+	OrgID       types.String   `tfsdk:"org_id"`
+	MetadataURL types.String   `tfsdk:"metadata_url"`
+	AuthType    types.String   `tfsdk:"auth_type"`
+	Enabled     types.Bool     `tfsdk:"enabled"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewSAMLResource() resource.Resource { return &samlResource{} }
@@ -32,20 +37,31 @@ func (r *samlResource) Metadata(_ context.Context, req resource.MetadataRequest,
 
 func (r *samlResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
-		resp.Diagnostics.AddError("Missing provider data", "internal: no client")
 		return
 	}
 	r.client = req.ProviderData.(*apiClient)
 }
 
-func (r *samlResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *samlResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Umbrella SAML Authentication Configuration",
 		Attributes: map[string]schema.Attribute{
-			"id":           schema.StringAttribute{Computed: true, Description: "SAML configuration ID"},
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "SAML configuration ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
 			"metadata_url": schema.StringAttribute{Required: true, Description: "SAML metadata URL from identity provider"},
 			"auth_type":    schema.StringAttribute{Required: true, Description: "Authentication type (e.g., AzureAD, ADFS)"},
-			"enabled":      schema.BoolAttribute{Computed: true, Description: "Whether SAML is enabled"},
+			"enabled": schema.BoolAttribute{
+				Computed:      true,
+				Description:   "Whether SAML is enabled",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true}),
 		},
 	}
 }
@@ -57,26 +73,28 @@ func (r *samlResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
 	payload := map[string]string{
 		"metadataUrl": plan.MetadataURL.ValueString(),
 		"authType":    plan.AuthType.ValueString(),
 	}
-	body, _ := json.Marshal(payload)
-
-	apiResp, err := r.client.do(ctx, http.MethodPut, fmt.Sprintf(samlPath, r.client.orgID), body)
-	if err != nil {
-		resp.Diagnostics.AddError("API error", err.Error())
-		return
-	}
-	defer apiResp.Body.Close()
-
-	if apiResp.StatusCode != http.StatusOK && apiResp.StatusCode != http.StatusCreated {
-		resp.Diagnostics.AddError("Create failed", fmt.Sprintf("HTTP %s", apiResp.Status))
+	if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(samlPath, orgID), payload, nil); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
 		return
 	}
 
 	// Use org ID as the ID since SAML config is org-level
-	plan.ID = types.StringValue(r.client.orgID)
+	plan.ID = types.StringValue(orgID)
 	plan.Enabled = types.BoolValue(true)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
@@ -89,30 +107,28 @@ func (r *samlResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	apiResp, err := r.client.do(ctx, http.MethodGet, fmt.Sprintf(samlPath, r.client.orgID), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("API error", err.Error())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	defer apiResp.Body.Close()
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-
-	if apiResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("Read failed", fmt.Sprintf("HTTP %s", apiResp.Status))
-		return
-	}
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
 
 	var samlConfig struct {
 		MetadataURL string `json:"metadataUrl"`
 		AuthType    string `json:"authType"`
 		Enabled     bool   `json:"enabled"`
 	}
-	if err := json.NewDecoder(apiResp.Body).Decode(&samlConfig); err != nil {
-		resp.Diagnostics.AddError("decode", err.Error())
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(samlPath, orgID), nil, &samlConfig); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
 		return
 	}
 
@@ -131,22 +147,24 @@ func (r *samlResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
 	if plan.MetadataURL != state.MetadataURL || plan.AuthType != state.AuthType {
 		payload := map[string]string{
 			"metadataUrl": plan.MetadataURL.ValueString(),
 			"authType":    plan.AuthType.ValueString(),
 		}
-		body, _ := json.Marshal(payload)
-
-		apiResp, err := r.client.do(ctx, http.MethodPut, fmt.Sprintf(samlPath, r.client.orgID), body)
-		if err != nil {
-			resp.Diagnostics.AddError("update SAML", err.Error())
-			return
-		}
-		defer apiResp.Body.Close()
-
-		if apiResp.StatusCode != http.StatusOK {
-			resp.Diagnostics.AddError("Update failed", fmt.Sprintf("HTTP %s", apiResp.Status))
+		if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(samlPath, orgID), payload, nil); err != nil {
+			resp.Diagnostics.AddError("Update failed", err.Error())
 			return
 		}
 	}
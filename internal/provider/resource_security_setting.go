@@ -0,0 +1,419 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Resource: umbrella_security_setting
+// -----------------------------------------------------------------------------
+
+type securitySettingResource struct{ client *apiClient }
+
+type securitySettingModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	OrgID              types.String   `tfsdk:"org_id"`
+	Name               types.String   `tfsdk:"name"`
+	Malware            types.Bool     `tfsdk:"malware"`
+	Phishing           types.Bool     `tfsdk:"phishing"`
+	CommandAndControl  types.Bool     `tfsdk:"command_and_control"`
+	NewlySeenDomains   types.Bool     `tfsdk:"newly_seen_domains"`
+	DNSTunneling       types.Bool     `tfsdk:"dns_tunneling"`
+	Cryptomining       types.Bool     `tfsdk:"cryptomining"`
+	PotentiallyHarmful types.Bool     `tfsdk:"potentially_harmful"`
+	DynamicDNS         types.Bool     `tfsdk:"dynamic_dns"`
+	Categories         types.Set      `tfsdk:"categories"`
+	CreatedAt          types.String   `tfsdk:"created_at"`
+	UpdatedAt          types.String   `tfsdk:"updated_at"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// securitySetting is the policies API representation of a security setting.
+// Categories holds the IDs of the blocked security categories.
+type securitySetting struct {
+	ID         int64   `json:"id"`
+	Name       string  `json:"name"`
+	Categories []int64 `json:"categories"`
+	CreatedAt  string  `json:"createdAt"`
+	ModifiedAt string  `json:"modifiedAt"`
+}
+
+// securityToggle ties a boolean attribute to the security category it blocks.
+type securityToggle struct {
+	category string
+	value    *types.Bool
+}
+
+func NewSecuritySettingResource() resource.Resource { return &securitySettingResource{} }
+
+func (r *securitySettingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "umbrella_security_setting"
+}
+
+func (r *securitySettingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*apiClient)
+}
+
+func (r *securitySettingResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	toggle := func(description string, def bool) schema.BoolAttribute {
+		return schema.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(def),
+			Description: fmt.Sprintf("%s (default: %t)", description, def),
+		}
+	}
+	resp.Schema = schema.Schema{
+		Description: "Umbrella Security Setting (blocked threat categories) for rulesets and DNS policies",
+		Attributes: map[string]schema.Attribute{
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Security setting ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name":                schema.StringAttribute{Required: true, Description: "Security setting name"},
+			"malware":             toggle("Block malware domains", true),
+			"phishing":            toggle("Block phishing attacks", true),
+			"command_and_control": toggle("Block command and control callbacks", true),
+			"newly_seen_domains":  toggle("Block newly seen domains", false),
+			"dns_tunneling":       toggle("Block DNS tunneling VPN services", false),
+			"cryptomining":        toggle("Block cryptomining", false),
+			"potentially_harmful": toggle("Block potentially harmful domains", false),
+			"dynamic_dns":         toggle("Block dynamic DNS domains", false),
+			"categories": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Names of further security categories to block, validated against the category catalogue. Categories covered by the toggles above cannot be listed here.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation timestamp",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"updated_at": schema.StringAttribute{Computed: true, Description: "Last update timestamp"},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+// ModifyPlan checks category names against the catalogue so that typos are
+// reported by plan rather than part-way through an apply.
+func (r *securitySettingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var plan securitySettingModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	var orgID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("org_id"), &orgID)...)
+	if resp.Diagnostics.HasError() || orgID.IsUnknown() || plan.Categories.IsUnknown() || len(plan.Categories.Elements()) == 0 {
+		return
+	}
+	for _, v := range plan.Categories.Elements() {
+		if v.IsUnknown() {
+			return
+		}
+	}
+
+	catalogue, err := r.client.securityCategories(ctx, r.client.resolveOrgID(orgID))
+	if err != nil {
+		// Umbrella may not be reachable yet (e.g. unknown credentials);
+		// apply checks the names again.
+		return
+	}
+	plan.payload(ctx, catalogue, &resp.Diagnostics)
+}
+
+// ------------------ CRUD ------------------
+
+func (r *securitySettingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan securitySettingModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	catalogue, err := r.client.securityCategories(ctx, orgID)
+	if err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+	payload := plan.payload(ctx, catalogue, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out securitySetting
+	if err := r.client.doJSON(ctx, http.MethodPost, fmt.Sprintf(securitySettingsPath, orgID), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(out.ID, 10))
+	out.toModel(&plan, catalogue)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *securitySettingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state securitySettingModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
+
+	var out securitySetting
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(securitySettingsPath+"/%s", orgID, state.ID.ValueString()), nil, &out); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+	catalogue, err := r.client.securityCategories(ctx, orgID)
+	if err != nil {
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+	out.toModel(&state, catalogue)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *securitySettingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state securitySettingModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	catalogue, err := r.client.securityCategories(ctx, orgID)
+	if err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+	payload := plan.payload(ctx, catalogue, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out securitySetting
+	if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(securitySettingsPath+"/%s", orgID, state.ID.ValueString()), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	out.toModel(&plan, catalogue)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *securitySettingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state securitySettingModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	err := r.client.doJSON(ctx, http.MethodDelete, fmt.Sprintf(securitySettingsPath+"/%s", orgID, state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+// ------------------ helpers ------------------
+
+func (m *securitySettingModel) toggles() []securityToggle {
+	return []securityToggle{
+		{"Malware", &m.Malware},
+		{"Phishing Attacks", &m.Phishing},
+		{"Command and Control Callbacks", &m.CommandAndControl},
+		{"Newly Seen Domains", &m.NewlySeenDomains},
+		{"DNS Tunneling VPN", &m.DNSTunneling},
+		{"Cryptomining", &m.Cryptomining},
+		{"Potentially Harmful", &m.PotentiallyHarmful},
+		{"Dynamic DNS", &m.DynamicDNS},
+	}
+}
+
+// payload resolves the enabled toggles and extra category names to catalogue
+// IDs, reporting unknown names and names already covered by a toggle.
+func (m *securitySettingModel) payload(ctx context.Context, catalogue []category, diags *diag.Diagnostics) map[string]interface{} {
+	byName := map[string]int64{}
+	for _, c := range catalogue {
+		byName[strings.ToLower(c.Label)] = c.ID
+	}
+	toggled := map[string]bool{}
+	ids := []int64{}
+	for _, t := range m.toggles() {
+		toggled[strings.ToLower(t.category)] = true
+		if !t.value.ValueBool() {
+			continue
+		}
+		id, ok := byName[strings.ToLower(t.category)]
+		if !ok {
+			diags.AddError("Unknown security category", fmt.Sprintf("the category catalogue has no %q category", t.category))
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	var unknown, duplicate []string
+	for _, name := range setToStringSlice(ctx, m.Categories, diags) {
+		if toggled[strings.ToLower(name)] {
+			duplicate = append(duplicate, name)
+			continue
+		}
+		id, ok := byName[strings.ToLower(name)]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		diags.AddAttributeError(path.Root("categories"), "Unknown security category",
+			fmt.Sprintf("not in the security category catalogue: %s (see the umbrella_categories data source)", strings.Join(unknown, ", ")))
+	}
+	if len(duplicate) > 0 {
+		sort.Strings(duplicate)
+		diags.AddAttributeError(path.Root("categories"), "Category managed by a toggle",
+			fmt.Sprintf("use the corresponding boolean attribute instead: %s", strings.Join(duplicate, ", ")))
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return map[string]interface{}{
+		"name":       m.Name.ValueString(),
+		"categories": ids,
+	}
+}
+
+// toModel maps the blocked category IDs back onto the toggles; anything else
+// lands in categories by name so out-of-band changes show up as drift.
+func (s securitySetting) toModel(m *securitySettingModel, catalogue []category) {
+	labels := map[int64]string{}
+	for _, c := range catalogue {
+		labels[c.ID] = c.Label
+	}
+	blocked := map[string]bool{}
+	for _, id := range s.Categories {
+		label, ok := labels[id]
+		if !ok {
+			label = strconv.FormatInt(id, 10)
+		}
+		blocked[strings.ToLower(label)] = true
+	}
+	toggled := map[string]bool{}
+	for _, t := range m.toggles() {
+		key := strings.ToLower(t.category)
+		toggled[key] = true
+		*t.value = types.BoolValue(blocked[key])
+	}
+	// Keep the configured spelling of names, since matching is case-insensitive.
+	configured := map[string]string{}
+	for _, v := range m.Categories.Elements() {
+		if name, ok := v.(types.String); ok {
+			configured[strings.ToLower(name.ValueString())] = name.ValueString()
+		}
+	}
+	extra := []string{}
+	for _, id := range s.Categories {
+		label, ok := labels[id]
+		if !ok {
+			label = strconv.FormatInt(id, 10)
+		}
+		if toggled[strings.ToLower(label)] {
+			continue
+		}
+		if name, ok := configured[strings.ToLower(label)]; ok {
+			label = name
+		}
+		extra = append(extra, label)
+	}
+
+	m.Name = types.StringValue(s.Name)
+	m.Categories = stringSetValue(m.Categories, extra)
+	m.CreatedAt = types.StringValue(s.CreatedAt)
+	m.UpdatedAt = types.StringValue(s.ModifiedAt)
+}
+
+// securityCategories returns the security categories of the catalogue.
+func (c *apiClient) securityCategories(ctx context.Context, orgID string) ([]category, error) {
+	categories, err := c.listCategories(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	out := []category{}
+	for _, cat := range categories {
+		if strings.EqualFold(cat.Type, "security") {
+			out = append(out, cat)
+		}
+	}
+	return out, nil
+}
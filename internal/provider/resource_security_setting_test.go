@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestSecuritySettingCategories checks that toggles and extra category names
+// are sent as catalogue IDs and read back without drift.
+func TestSecuritySettingCategories(t *testing.T) {
+	f := newFakeUmbrella(t)
+	r := newTFHarness(t, f.api.client).resource("umbrella_security_setting")
+	attrs := map[string]interface{}{
+		"name":         "baseline",
+		"cryptomining": true,
+		"categories":   []string{"potentially unwanted applications"},
+	}
+
+	r.apply("create", r.config(attrs))
+	r.expectNoChanges("after create", r.config(attrs))
+	body := f.received(http.MethodPost, `/securitysettings$`)[0].body.(map[string]interface{})
+	// Malware, phishing and C2 are on by default.
+	if got := fmt.Sprint(body["categories"]); got != "[100 101 102 105 108]" {
+		t.Errorf("categories sent = %s, want [100 101 102 105 108]", got)
+	}
+	r.destroy("destroy")
+}
+
+// TestSecuritySettingUnknownCategory checks that category names missing from
+// the catalogue, or covered by a toggle, are reported at plan time.
+func TestSecuritySettingUnknownCategory(t *testing.T) {
+	f := newFakeUmbrella(t)
+	r := newTFHarness(t, f.api.client).resource("umbrella_security_setting")
+
+	for _, tc := range []struct {
+		categories []string
+		want       string
+	}{
+		{[]string{"Potentially Unwanted Applications", "Malwar", "Gambling"}, "not in the security category catalogue: Gambling, Malwar"},
+		{[]string{"Dynamic DNS"}, "use the corresponding boolean attribute instead: Dynamic DNS"},
+	} {
+		config := r.config(map[string]interface{}{"name": "baseline", "categories": tc.categories})
+		if msg := r.validate(config); !strings.Contains(msg, tc.want) {
+			t.Errorf("categories %v: got %q, want an error containing %q", tc.categories, msg, tc.want)
+		}
+	}
+	if got := f.received(http.MethodPost, `/securitysettings$`); len(got) != 0 {
+		t.Errorf("got %d create requests, want none", len(got))
+	}
+}
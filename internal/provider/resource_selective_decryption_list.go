@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Resource: umbrella_selective_decryption_list
+// -----------------------------------------------------------------------------
+
+type selectiveDecryptionListResource struct{ client *apiClient }
+
+type selectiveDecryptionListModel struct {
+	ID           types.String   `tfsdk:"id"`
+	OrgID        types.String   `tfsdk:"org_id"`
+	Name         types.String   `tfsdk:"name"`
+	Categories   types.Set      `tfsdk:"categories"`
+	Applications types.Set      `tfsdk:"applications"`
+	Domains      types.Set      `tfsdk:"domains"`
+	CreatedAt    types.String   `tfsdk:"created_at"`
+	UpdatedAt    types.String   `tfsdk:"updated_at"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// selectiveDecryptionList is the policies API representation of a selective
+// decryption (do-not-decrypt) list.
+type selectiveDecryptionList struct {
+	ID           int64    `json:"id"`
+	Name         string   `json:"name"`
+	Categories   []int64  `json:"categories"`
+	Applications []int64  `json:"applications"`
+	Domains      []string `json:"domains"`
+	CreatedAt    string   `json:"createdAt"`
+	ModifiedAt   string   `json:"modifiedAt"`
+}
+
+func NewSelectiveDecryptionListResource() resource.Resource {
+	return &selectiveDecryptionListResource{}
+}
+
+func (r *selectiveDecryptionListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "umbrella_selective_decryption_list"
+}
+
+func (r *selectiveDecryptionListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*apiClient)
+}
+
+func (r *selectiveDecryptionListResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Umbrella Selective Decryption List (traffic exempt from SSL decryption)",
+		Attributes: map[string]schema.Attribute{
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Selective decryption list ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name":         schema.StringAttribute{Required: true, Description: "Selective decryption list name"},
+			"categories":   schema.SetAttribute{Optional: true, ElementType: types.Int64Type, Description: "Content category IDs not to decrypt, see the umbrella_categories data source"},
+			"applications": schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "Application IDs not to decrypt, see the umbrella_applications data source"},
+			"domains":      schema.SetAttribute{Optional: true, ElementType: types.StringType, Description: "Domains not to decrypt"},
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation timestamp",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"updated_at": schema.StringAttribute{Computed: true, Description: "Last update timestamp"},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+// ------------------ CRUD ------------------
+
+func (r *selectiveDecryptionListResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan selectiveDecryptionListModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload := plan.payload(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out selectiveDecryptionList
+	if err := r.client.doJSON(ctx, http.MethodPost, fmt.Sprintf(selectiveDecryptionListsPath, orgID), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(strconv.FormatInt(out.ID, 10))
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *selectiveDecryptionListResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state selectiveDecryptionListModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
+
+	var out selectiveDecryptionList
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(selectiveDecryptionListsPath+"/%s", orgID, state.ID.ValueString()), nil, &out); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+	out.toModel(&state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *selectiveDecryptionListResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state selectiveDecryptionListModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload := plan.payload(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out selectiveDecryptionList
+	if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(selectiveDecryptionListsPath+"/%s", orgID, state.ID.ValueString()), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *selectiveDecryptionListResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state selectiveDecryptionListModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	err := r.client.doJSON(ctx, http.MethodDelete, fmt.Sprintf(selectiveDecryptionListsPath+"/%s", orgID, state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+// ------------------ helpers ------------------
+
+func (m selectiveDecryptionListModel) payload(ctx context.Context, diags *diag.Diagnostics) map[string]interface{} {
+	applications := []int64{}
+	for _, a := range setToStringSlice(ctx, m.Applications, diags) {
+		id, err := strconv.ParseInt(a, 10, 64)
+		if err != nil {
+			diags.AddAttributeError(path.Root("applications"), "Invalid application ID", fmt.Sprintf("%q is not a numeric application ID", a))
+			continue
+		}
+		applications = append(applications, id)
+	}
+	return map[string]interface{}{
+		"name":         m.Name.ValueString(),
+		"categories":   setToInt64Slice(ctx, m.Categories, diags),
+		"applications": applications,
+		"domains":      setToStringSlice(ctx, m.Domains, diags),
+	}
+}
+
+func (l selectiveDecryptionList) toModel(m *selectiveDecryptionListModel) {
+	applications := []string{}
+	for _, id := range l.Applications {
+		applications = append(applications, strconv.FormatInt(id, 10))
+	}
+	m.Name = types.StringValue(l.Name)
+	m.Categories = int64SetValue(m.Categories, l.Categories)
+	m.Applications = stringSetValue(m.Applications, applications)
+	m.Domains = stringSetValue(m.Domains, l.Domains)
+	m.CreatedAt = types.StringValue(l.CreatedAt)
+	m.UpdatedAt = types.StringValue(l.ModifiedAt)
+}
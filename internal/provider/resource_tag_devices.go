@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Resource: umbrella_tag_devices
+// -----------------------------------------------------------------------------
+
+// tagDevicesBatchSize caps the origins sent in one add/remove request so very
+// large retagging runs stay within the API's request size limits.
+const tagDevicesBatchSize = 500
+
+type tagDevicesResource struct{ client *apiClient }
+
+type tagDevicesModel struct {
+	ID        types.String   `tfsdk:"id"`
+	OrgID     types.String   `tfsdk:"org_id"`
+	TagID     types.String   `tfsdk:"tag_id"`
+	DeviceIDs types.Set      `tfsdk:"device_ids"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewTagDevicesResource() resource.Resource { return &tagDevicesResource{} }
+
+func (r *tagDevicesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "umbrella_tag_devices"
+}
+
+func (r *tagDevicesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*apiClient)
+}
+
+func (r *tagDevicesResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Authoritative device membership of an Umbrella tag. Devices not listed here are removed from the tag.",
+		Attributes: map[string]schema.Attribute{
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Tag ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"tag_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "ID of the tag",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"device_ids": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "Origin IDs of the devices carrying the tag, see the umbrella_roaming_computers data source",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+// ------------------ CRUD ------------------
+
+func (r *tagDevicesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan tagDevicesModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	// The tag may already carry devices; converge on the configured set.
+	current, err := r.getTagDevices(ctx, orgID, plan.TagID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+	desired := setToStringSlice(ctx, plan.DeviceIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	toAdd, toDel := diffSlices(current, desired)
+	if err := r.syncTagDevices(ctx, orgID, plan.TagID.ValueString(), toDel, toAdd); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+
+	plan.ID = plan.TagID
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *tagDevicesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state tagDevicesModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
+
+	devices, err := r.getTagDevices(ctx, orgID, state.TagID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+	state.DeviceIDs = stringSetValue(state.DeviceIDs, devices)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *tagDevicesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state tagDevicesModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	// ---- device diff logic ----
+	desired := setToStringSlice(ctx, plan.DeviceIDs, &resp.Diagnostics)
+	current := setToStringSlice(ctx, state.DeviceIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	toAdd, toDel := diffSlices(current, desired)
+	if err := r.syncTagDevices(ctx, orgID, state.TagID.ValueString(), toDel, toAdd); err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *tagDevicesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state tagDevicesModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+
+	devices := setToStringSlice(ctx, state.DeviceIDs, &resp.Diagnostics)
+	err := r.syncTagDevices(ctx, orgID, state.TagID.ValueString(), devices, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
+	}
+}
+
+// ------------------ helpers ------------------
+
+func (r *tagDevicesResource) getTagDevices(ctx context.Context, orgID, tagID string) ([]string, error) {
+	devices, err := getAllPages[struct {
+		OriginID int64 `json:"originId"`
+	}](ctx, r.client, fmt.Sprintf(tagsPath+"/%s/devices", orgID, tagID))
+	if err != nil {
+		return nil, err
+	}
+	vals := []string{}
+	for _, d := range devices {
+		vals = append(vals, strconv.FormatInt(d.OriginID, 10))
+	}
+	return vals, nil
+}
+
+// syncTagDevices applies a membership diff in batches of
+// tagDevicesBatchSize, adding before removing.
+func (r *tagDevicesResource) syncTagDevices(ctx context.Context, orgID, tagID string, remove []string, add []string) error {
+	path := fmt.Sprintf(tagsPath+"/%s/devices", orgID, tagID)
+	send := func(key string, ids []string) error {
+		origins, err := parseOriginIDs(ids)
+		if err != nil {
+			return err
+		}
+		for start := 0; start < len(origins); start += tagDevicesBatchSize {
+			end := start + tagDevicesBatchSize
+			if end > len(origins) {
+				end = len(origins)
+			}
+			payload := map[string][]int64{key: origins[start:end]}
+			if err := r.client.doJSON(ctx, http.MethodPost, path, payload, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := send("addOrigins", add); err != nil {
+		return fmt.Errorf("add devices: %w", err)
+	}
+	if err := send("removeOrigins", remove); err != nil {
+		return fmt.Errorf("remove devices: %w", err)
+	}
+	return nil
+}
+
+func parseOriginIDs(ids []string) ([]int64, error) {
+	out := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		v, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("device ID %q is not a numeric origin ID", id)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
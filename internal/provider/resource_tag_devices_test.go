@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// TestTagDevicesBatches retags a fleet larger than tagDevicesBatchSize and
+// checks that only the membership diff is sent, split into batches.
+func TestTagDevicesBatches(t *testing.T) {
+	f := newFakeUmbrella(t)
+	f.seed(map[string]interface{}{"id": 200, "name": "laptops"}, tagsPath+"/200", "1234")
+	r := newTFHarness(t, f.api.client).resource("umbrella_tag_devices")
+
+	devices := func(from, to int) []string {
+		ids := []string{}
+		for id := from; id <= to; id++ {
+			ids = append(ids, strconv.Itoa(id))
+		}
+		return ids
+	}
+	// batches returns the size of each add or remove batch sent since the
+	// previous call.
+	sent := 0
+	batches := func() (add, remove []int) {
+		posts := f.received(http.MethodPost, `/tags/200/devices$`)
+		for _, req := range posts[sent:] {
+			body := req.body.(map[string]interface{})
+			if ids, ok := body["addOrigins"].([]interface{}); ok {
+				add = append(add, len(ids))
+			}
+			if ids, ok := body["removeOrigins"].([]interface{}); ok {
+				remove = append(remove, len(ids))
+			}
+		}
+		sent = len(posts)
+		return add, remove
+	}
+	expectBatches := func(step string, wantAdd, wantRemove []int) {
+		t.Helper()
+		add, remove := batches()
+		if !reflect.DeepEqual(add, wantAdd) || !reflect.DeepEqual(remove, wantRemove) {
+			t.Errorf("%s: sent add batches %v and remove batches %v, want %v and %v", step, add, remove, wantAdd, wantRemove)
+		}
+	}
+
+	config := r.config(map[string]interface{}{"tag_id": "200", "device_ids": devices(1, 600)})
+	r.apply("create", config)
+	expectBatches("create", []int{500, 100}, nil)
+
+	config = r.config(map[string]interface{}{"tag_id": "200", "device_ids": devices(601, 1200)})
+	r.apply("replace all", config)
+	expectBatches("replace all", []int{500, 100}, []int{500, 100})
+	r.expectNoChanges("after replace all", config)
+
+	r.destroy("destroy")
+	expectBatches("destroy", nil, []int{500, 100})
+}
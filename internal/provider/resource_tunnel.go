@@ -2,12 +2,17 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -18,17 +23,19 @@ import (
 type tunnelResource struct{ client *apiClient }
 
 type tunnelModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	SiteOriginID   types.Int64  `tfsdk:"site_origin_id"`
-	DeviceIP       types.String `tfsdk:"device_ip"`
-	PreSharedKey   types.String `tfsdk:"pre_shared_key"`
-	LocalNetworks  types.List   `tfsdk:"local_networks"`
-	TunnelType     types.String `tfsdk:"tunnel_type"`
-	Status         types.String `tfsdk:"status"`
-	TunnelEndpoint types.String `tfsdk:"tunnel_endpoint"`
-	CreatedAt      types.String `tfsdk:"created_at"`
-	UpdatedAt      types.String `tfsdk:"updated_at"`
+	ID             types.String   `tfsdk:"id"`
+	OrgID          types.String   `tfsdk:"org_id"`
+	Name           types.String   `tfsdk:"name"`
+	SiteOriginID   types.Int64    `tfsdk:"site_origin_id"`
+	DeviceIP       types.String   `tfsdk:"device_ip"`
+	PreSharedKey   types.String   `tfsdk:"pre_shared_key"`
+	LocalNetworks  types.List     `tfsdk:"local_networks"`
+	TunnelType     types.String   `tfsdk:"tunnel_type"`
+	Status         types.String   `tfsdk:"status"`
+	TunnelEndpoint types.String   `tfsdk:"tunnel_endpoint"`
+	CreatedAt      types.String   `tfsdk:"created_at"`
+	UpdatedAt      types.String   `tfsdk:"updated_at"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewTunnelResource() resource.Resource { return &tunnelResource{} }
@@ -39,31 +46,56 @@ func (r *tunnelResource) Metadata(_ context.Context, req resource.MetadataReques
 
 func (r *tunnelResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
-		resp.Diagnostics.AddError("Missing provider data", "internal: no client")
 		return
 	}
 	r.client = req.ProviderData.(*apiClient)
 }
 
-func (r *tunnelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *tunnelResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Umbrella IPSec Tunnel for Secure Internet Gateway",
 		Attributes: map[string]schema.Attribute{
-			"id":              schema.StringAttribute{Computed: true, Description: "Tunnel ID"},
-			"name":            schema.StringAttribute{Required: true, Description: "Tunnel name"},
-			"site_origin_id":  schema.Int64Attribute{Required: true, Description: "Site origin ID to associate with the tunnel"},
-			"device_ip":       schema.StringAttribute{Required: true, Description: "Public IP address of the device that will establish the IPSec tunnel"},
-			"pre_shared_key":  schema.StringAttribute{Required: true, Sensitive: true, Description: "Pre-shared key for IPSec authentication"},
-			"local_networks":  schema.ListAttribute{ElementType: types.StringType, Required: true, Description: "List of local network CIDR blocks that will use this tunnel"},
-			"tunnel_type":     schema.StringAttribute{Optional: true, Computed: true, Description: "Type of tunnel (default: IPSEC)"},
-			"status":          schema.StringAttribute{Computed: true, Description: "Current status of the tunnel"},
-			"tunnel_endpoint": schema.StringAttribute{Computed: true, Description: "Umbrella tunnel endpoint IP address"},
-			"created_at":      schema.StringAttribute{Computed: true, Description: "Creation timestamp in ISO 8601 format"},
-			"updated_at":      schema.StringAttribute{Computed: true, Description: "Last update timestamp in ISO 8601 format"},
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Tunnel ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name":           schema.StringAttribute{Required: true, Description: "Tunnel name"},
+			"site_origin_id": schema.Int64Attribute{Required: true, Description: "Site origin ID to associate with the tunnel"},
+			"device_ip":      schema.StringAttribute{Required: true, Description: "Public IP address of the device that will establish the IPSec tunnel"},
+			"pre_shared_key": schema.StringAttribute{Required: true, Sensitive: true, Description: "Pre-shared key for IPSec authentication"},
+			"local_networks": schema.ListAttribute{ElementType: types.StringType, Required: true, Description: "List of local network CIDR blocks that will use this tunnel"},
+			"tunnel_type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("IPSEC"),
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+				Description: "Type of tunnel (default: IPSEC)",
+			},
+			"status": schema.StringAttribute{Computed: true, Description: "Current status of the tunnel"},
+			"tunnel_endpoint": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Umbrella tunnel endpoint IP address",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Creation timestamp in ISO 8601 format",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"updated_at": schema.StringAttribute{Computed: true, Description: "Last update timestamp in ISO 8601 format"},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
 		},
 	}
 }
 
+func (r *tunnelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateWithOrgID(ctx, req, resp)
+}
+
 // ------------------ CRUD ------------------
 
 func (r *tunnelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -73,6 +105,17 @@ func (r *tunnelResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
 	// Extract local networks from the list
 	var localNetworks []string
 	resp.Diagnostics.Append(plan.LocalNetworks.ElementsAs(ctx, &localNetworks, false)...)
@@ -94,20 +137,6 @@ func (r *tunnelResource) Create(ctx context.Context, req resource.CreateRequest,
 		"localNetworks": localNetworks,
 		"tunnelType":    tunnelType,
 	}
-	body, _ := json.Marshal(payload)
-
-	apiResp, err := r.client.do(ctx, http.MethodPost, fmt.Sprintf(tunnelPath, r.client.orgID), body)
-	if err != nil {
-		resp.Diagnostics.AddError("API error", err.Error())
-		return
-	}
-	defer apiResp.Body.Close()
-
-	if apiResp.StatusCode != http.StatusCreated && apiResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("Create failed", fmt.Sprintf("HTTP %s", apiResp.Status))
-		return
-	}
-
 	var data struct {
 		ID             string   `json:"id"`
 		Name           string   `json:"name"`
@@ -120,8 +149,8 @@ func (r *tunnelResource) Create(ctx context.Context, req resource.CreateRequest,
 		CreatedAt      string   `json:"createdAt"`
 		UpdatedAt      string   `json:"updatedAt"`
 	}
-	if err := json.NewDecoder(apiResp.Body).Decode(&data); err != nil {
-		resp.Diagnostics.AddError("decode", err.Error())
+	if err := r.client.doJSON(ctx, http.MethodPost, fmt.Sprintf(tunnelPath, orgID), payload, &data); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
 		return
 	}
 
@@ -151,22 +180,16 @@ func (r *tunnelResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	apiResp, err := r.client.do(ctx, http.MethodGet, fmt.Sprintf(tunnelPath+"/%s", r.client.orgID, state.ID.ValueString()), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("API error", err.Error())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	defer apiResp.Body.Close()
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-
-	if apiResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("Read failed", fmt.Sprintf("HTTP %s", apiResp.Status))
-		return
-	}
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
 
 	var tunnel struct {
 		ID             string   `json:"id"`
@@ -180,8 +203,12 @@ func (r *tunnelResource) Read(ctx context.Context, req resource.ReadRequest, res
 		CreatedAt      string   `json:"createdAt"`
 		UpdatedAt      string   `json:"updatedAt"`
 	}
-	if err := json.NewDecoder(apiResp.Body).Decode(&tunnel); err != nil {
-		resp.Diagnostics.AddError("decode", err.Error())
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(tunnelPath+"/%s", orgID, state.ID.ValueString()), nil, &tunnel); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
 		return
 	}
 
@@ -213,6 +240,17 @@ func (r *tunnelResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
 	// Check if any updateable fields have changed
 	if plan.Name != state.Name || plan.SiteOriginID != state.SiteOriginID || plan.DeviceIP != state.DeviceIP ||
 		plan.PreSharedKey != state.PreSharedKey || !plan.LocalNetworks.Equal(state.LocalNetworks) ||
@@ -239,20 +277,6 @@ func (r *tunnelResource) Update(ctx context.Context, req resource.UpdateRequest,
 			"localNetworks": localNetworks,
 			"tunnelType":    tunnelType,
 		}
-		body, _ := json.Marshal(payload)
-
-		apiResp, err := r.client.do(ctx, http.MethodPut, fmt.Sprintf(tunnelPath+"/%s", r.client.orgID, state.ID.ValueString()), body)
-		if err != nil {
-			resp.Diagnostics.AddError("update tunnel", err.Error())
-			return
-		}
-		defer apiResp.Body.Close()
-
-		if apiResp.StatusCode != http.StatusOK {
-			resp.Diagnostics.AddError("Update failed", fmt.Sprintf("HTTP %s", apiResp.Status))
-			return
-		}
-
 		var data struct {
 			ID             string   `json:"id"`
 			Name           string   `json:"name"`
@@ -265,8 +289,8 @@ func (r *tunnelResource) Update(ctx context.Context, req resource.UpdateRequest,
 			CreatedAt      string   `json:"createdAt"`
 			UpdatedAt      string   `json:"updatedAt"`
 		}
-		if err := json.NewDecoder(apiResp.Body).Decode(&data); err != nil {
-			resp.Diagnostics.AddError("decode", err.Error())
+		if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(tunnelPath+"/%s", orgID, state.ID.ValueString()), payload, &data); err != nil {
+			resp.Diagnostics.AddError("Update failed", err.Error())
 			return
 		}
 
@@ -283,6 +307,11 @@ func (r *tunnelResource) Update(ctx context.Context, req resource.UpdateRequest,
 		plan.Status = types.StringValue(data.Status)
 		plan.TunnelEndpoint = types.StringValue(data.TunnelEndpoint)
 		plan.UpdatedAt = types.StringValue(data.UpdatedAt)
+	} else {
+		// Nothing Umbrella stores changed (e.g. only timeouts), so keep the
+		// computed values rather than leaving them unknown.
+		plan.Status = state.Status
+		plan.UpdatedAt = state.UpdatedAt
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
@@ -295,14 +324,18 @@ func (r *tunnelResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	apiResp, err := r.client.do(ctx, http.MethodDelete, fmt.Sprintf(tunnelPath+"/%s", r.client.orgID, state.ID.ValueString()), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("delete", err.Error())
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	defer apiResp.Body.Close()
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
 
-	if apiResp.StatusCode != http.StatusNoContent && apiResp.StatusCode != http.StatusOK {
-		resp.Diagnostics.AddError("Delete failed", fmt.Sprintf("HTTP %s", apiResp.Status))
+	err := r.client.doJSON(ctx, http.MethodDelete, fmt.Sprintf(tunnelPath+"/%s", orgID, state.ID.ValueString()), nil, nil)
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError("Delete failed", err.Error())
 	}
 }
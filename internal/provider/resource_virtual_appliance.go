@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// -----------------------------------------------------------------------------
+// Resource: umbrella_virtual_appliance
+// -----------------------------------------------------------------------------
+
+type virtualApplianceResource struct{ client *apiClient }
+
+type virtualApplianceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	OrgID       types.String   `tfsdk:"org_id"`
+	ApplianceID types.String   `tfsdk:"appliance_id"`
+	Name        types.String   `tfsdk:"name"`
+	SiteID      types.Int64    `tfsdk:"site_id"`
+	UpstreamDNS types.List     `tfsdk:"upstream_dns"`
+	Health      types.String   `tfsdk:"health"`
+	Version     types.String   `tfsdk:"version"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewVirtualApplianceResource() resource.Resource { return &virtualApplianceResource{} }
+
+func (r *virtualApplianceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "umbrella_virtual_appliance"
+}
+
+func (r *virtualApplianceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*apiClient)
+}
+
+func (r *virtualApplianceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Editable settings of an enrolled Umbrella Virtual Appliance. Appliances register themselves; this resource adopts one and destroying it leaves the appliance in place.",
+		Attributes: map[string]schema.Attribute{
+			"org_id": orgIDAttribute(),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Appliance origin ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"appliance_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "Origin ID of the appliance, see the umbrella_virtual_appliances data source",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name":    schema.StringAttribute{Required: true, Description: "Appliance name"},
+			"site_id": schema.Int64Attribute{Required: true, Description: "Site the appliance is assigned to"},
+			"upstream_dns": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Upstream (local) DNS servers used for internal domains, in order. Left unmanaged when omitted.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(ipAddressValidator{}),
+				},
+			},
+			"health":  schema.StringAttribute{Computed: true, Description: "Health status reported by the appliance"},
+			"version": schema.StringAttribute{Computed: true, Description: "Appliance software version"},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true}),
+		},
+	}
+}
+
+// ------------------ CRUD ------------------
+
+func (r *virtualApplianceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan virtualApplianceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(plan.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	// The appliance already exists; creating the resource just applies the
+	// configured settings to it.
+	payload := plan.payload(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out virtualAppliance
+	if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(virtualAppliancesPath+"/%s", orgID, plan.ApplianceID.ValueString()), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Create failed", err.Error())
+		return
+	}
+
+	plan.ID = plan.ApplianceID
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *virtualApplianceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state virtualApplianceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	state.OrgID = types.StringValue(orgID)
+
+	var out virtualAppliance
+	if err := r.client.doJSON(ctx, http.MethodGet, fmt.Sprintf(virtualAppliancesPath+"/%s", orgID, state.ApplianceID.ValueString()), nil, &out); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Read failed", err.Error())
+		return
+	}
+	out.toModel(&state)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *virtualApplianceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state virtualApplianceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	orgID := r.client.resolveOrgID(state.OrgID)
+	plan.OrgID = types.StringValue(orgID)
+
+	payload := plan.payload(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var out virtualAppliance
+	if err := r.client.doJSON(ctx, http.MethodPut, fmt.Sprintf(virtualAppliancesPath+"/%s", orgID, state.ApplianceID.ValueString()), payload, &out); err != nil {
+		resp.Diagnostics.AddError("Update failed", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	out.toModel(&plan)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *virtualApplianceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Virtual Appliances are removed by decommissioning the VM, not through
+	// the API. Destroying the resource only stops managing its settings.
+}
+
+// ------------------ helpers ------------------
+
+func (m virtualApplianceModel) payload(ctx context.Context, diags *diag.Diagnostics) map[string]interface{} {
+	payload := map[string]interface{}{
+		"name":   m.Name.ValueString(),
+		"siteId": m.SiteID.ValueInt64(),
+	}
+	if !m.UpstreamDNS.IsNull() && !m.UpstreamDNS.IsUnknown() {
+		var servers []string
+		diags.Append(m.UpstreamDNS.ElementsAs(ctx, &servers, false)...)
+		payload["settings"] = map[string]interface{}{"upstreamDns": servers}
+	}
+	return payload
+}
+
+func (va virtualAppliance) toModel(m *virtualApplianceModel) {
+	m.Name = types.StringValue(va.Name)
+	m.SiteID = types.Int64Value(va.SiteID)
+	m.Health = types.StringValue(va.Health)
+	m.Version = types.StringValue(va.Settings.Version)
+	// upstream_dns is only tracked once configured, otherwise the servers the
+	// appliance was deployed with would show up as drift.
+	if !m.UpstreamDNS.IsNull() {
+		m.UpstreamDNS, _ = types.ListValue(types.StringType, stringSliceToAttrValues(va.Settings.UpstreamDNS))
+	}
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestVirtualApplianceAdoptAndRelease checks that the resource adopts an
+// enrolled appliance, keeps the upstream DNS order, and leaves the appliance
+// in place on destroy.
+func TestVirtualApplianceAdoptAndRelease(t *testing.T) {
+	f := newFakeUmbrella(t)
+	f.seed(map[string]interface{}{
+		"originId": 500, "name": "va-1", "siteId": 1, "health": "healthy",
+		"settings": map[string]interface{}{"version": "3.1", "upstreamDns": []string{"10.0.0.53"}},
+	}, virtualAppliancesPath+"/500", "1234")
+	r := newTFHarness(t, f.api.client).resource("umbrella_virtual_appliance")
+
+	attrs := map[string]interface{}{"appliance_id": "500", "name": "branch-va", "site_id": 2}
+	r.apply("adopt", r.config(attrs))
+	r.expectNoChanges("after adopt", r.config(attrs))
+	if got := r.stateString("version"); got != "3.1" {
+		t.Errorf("adopt: version = %q, want 3.1", got)
+	}
+
+	attrs["upstream_dns"] = []string{"10.10.1.53", "10.10.0.53"}
+	r.apply("set upstream DNS", r.config(attrs))
+	r.expectNoChanges("after set upstream DNS", r.config(attrs))
+	settings := f.object(virtualAppliancesPath+"/500", "1234")["settings"].(map[string]interface{})
+	if got := settings["upstreamDns"]; !reflect.DeepEqual(got, []interface{}{"10.10.1.53", "10.10.0.53"}) {
+		t.Errorf("set upstream DNS: upstreamDns = %v, want [10.10.1.53 10.10.0.53]", got)
+	}
+
+	r.destroy("destroy")
+	if got := f.object(virtualAppliancesPath+"/500", "1234")["name"]; got != "branch-va" {
+		t.Errorf("destroy: appliance name = %v, want it left as branch-va", got)
+	}
+}
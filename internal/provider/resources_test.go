@@ -0,0 +1,335 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// resourceTestCase is the minimal configuration of one resource for
+// TestResourcesOptionalAttributes.
+type resourceTestCase struct {
+	required map[string]interface{}
+	// rejectEmpty lists optional attributes ("block.attr" inside blocks)
+	// for which an empty value is invalid and must fail validate or plan.
+	rejectEmpty []string
+	// seed creates the objects the resource manages or refers to.
+	seed func(f *fakeUmbrella)
+}
+
+var resourceTestCases = map[string]resourceTestCase{
+	"umbrella_destination_list": {
+		required:    map[string]interface{}{"name": "blocked", "type": "DOMAIN"},
+		rejectEmpty: []string{"org_id"},
+	},
+	"umbrella_destination": {
+		required:    map[string]interface{}{"destination_list_id": "100", "destination": "example.com"},
+		rejectEmpty: []string{"org_id"},
+		seed: func(f *fakeUmbrella) {
+			f.seed(map[string]interface{}{"id": 100, "name": "blocked"}, destListPath+"/100", "1234")
+		},
+	},
+	"umbrella_tunnel": {
+		required: map[string]interface{}{
+			"name": "branch", "site_origin_id": 1, "device_ip": "198.51.100.7",
+			"pre_shared_key": "secret", "local_networks": []string{"10.1.0.0/16"},
+		},
+		rejectEmpty: []string{"org_id", "tunnel_type"},
+	},
+	"umbrella_saml": {
+		required:    map[string]interface{}{"metadata_url": "https://idp.example.com/metadata", "auth_type": "SAML"},
+		rejectEmpty: []string{"org_id"},
+	},
+	"umbrella_ruleset": {
+		required:    map[string]interface{}{"name": "default"},
+		rejectEmpty: []string{"org_id"},
+	},
+	"umbrella_rule": {
+		required:    map[string]interface{}{"ruleset_id": "10", "name": "block social", "action": "BLOCK", "rank": 1},
+		rejectEmpty: []string{"org_id", "content_category_setting"},
+	},
+	"umbrella_site": {
+		required:    map[string]interface{}{"name": "branch"},
+		rejectEmpty: []string{"org_id"},
+	},
+	"umbrella_network": {
+		required:    map[string]interface{}{"name": "egress", "prefix_length": 32},
+		rejectEmpty: []string{"org_id"},
+	},
+	"umbrella_internal_network": {
+		required:    map[string]interface{}{"name": "lan", "cidr": "10.2.0.0/24", "site_id": 1},
+		rejectEmpty: []string{"org_id", "network_id", "tunnel_id"},
+	},
+	"umbrella_internal_domain": {
+		required:    map[string]interface{}{"domain": "corp.example.com"},
+		rejectEmpty: []string{"org_id"},
+	},
+	"umbrella_dns_policy": {
+		required:    map[string]interface{}{"name": "office"},
+		rejectEmpty: []string{"org_id"},
+	},
+	"umbrella_content_category_setting": {
+		required:    map[string]interface{}{"name": "strict", "categories": []int{1, 2}},
+		rejectEmpty: []string{"org_id"},
+	},
+	"umbrella_security_setting": {
+		required:    map[string]interface{}{"name": "baseline"},
+		rejectEmpty: []string{"org_id"},
+	},
+	"umbrella_selective_decryption_list": {
+		required:    map[string]interface{}{"name": "banking"},
+		rejectEmpty: []string{"org_id"},
+	},
+	"umbrella_block_page": {
+		required:    map[string]interface{}{"name": "blocked"},
+		rejectEmpty: []string{"org_id", "logo_file"},
+	},
+	"umbrella_warn_page": {
+		required:    map[string]interface{}{"name": "warned"},
+		rejectEmpty: []string{"org_id", "logo_file"},
+	},
+	"umbrella_bypass_code": {
+		required:    map[string]interface{}{"valid_for": "24h"},
+		rejectEmpty: []string{"org_id"},
+	},
+	"umbrella_bypass_user": {
+		required:    map[string]interface{}{"email": "jane@example.com"},
+		rejectEmpty: []string{"org_id"},
+	},
+	"umbrella_roaming_computer_tags": {
+		required:    map[string]interface{}{"device_id": "device-1", "tags": []string{"laptops"}},
+		rejectEmpty: []string{"org_id"},
+		seed: func(f *fakeUmbrella) {
+			f.seed(map[string]interface{}{"deviceId": "device-1", "originId": 300, "tags": []string{}}, roamingComputersPath+"/device-1", "1234")
+		},
+	},
+	"umbrella_tag": {
+		required:    map[string]interface{}{"name": "laptops"},
+		rejectEmpty: []string{"org_id"},
+	},
+	"umbrella_tag_devices": {
+		required:    map[string]interface{}{"tag_id": "200", "device_ids": []string{"300"}},
+		rejectEmpty: []string{"org_id"},
+		seed: func(f *fakeUmbrella) {
+			f.seed(map[string]interface{}{"id": 200, "name": "laptops"}, tagsPath+"/200", "1234")
+		},
+	},
+	"umbrella_virtual_appliance": {
+		required:    map[string]interface{}{"appliance_id": "500", "name": "va-1", "site_id": 1},
+		rejectEmpty: []string{"org_id", "upstream_dns"},
+		seed: func(f *fakeUmbrella) {
+			f.seed(map[string]interface{}{
+				"originId": 500, "name": "va-1", "siteId": 1, "health": "healthy",
+				"settings": map[string]interface{}{"version": "3.1", "upstreamDns": []string{"10.0.0.53"}},
+			}, virtualAppliancesPath+"/500", "1234")
+		},
+	},
+}
+
+// TestResourcesOptionalAttributes plans and applies every resource with its
+// optional attributes left null, then set to empty values, and checks that
+// each apply matches its plan and that nothing drifts afterwards.
+func TestResourcesOptionalAttributes(t *testing.T) {
+	h := newTFHarness(t, nil)
+	for typeName := range h.schemas {
+		if _, ok := resourceTestCases[typeName]; !ok {
+			t.Errorf("%s has no entry in resourceTestCases", typeName)
+		}
+	}
+
+	for typeName, tc := range resourceTestCases {
+		typeName, tc := typeName, tc
+		t.Run(typeName, func(t *testing.T) {
+			f := newFakeUmbrella(t)
+			if tc.seed != nil {
+				tc.seed(f)
+			}
+			h := newTFHarness(t, f.api.client)
+			r := h.resource(typeName)
+
+			empty := emptyOptionals(r.schema.Block)
+			for _, name := range tc.rejectEmpty {
+				deleteAttribute(empty, name)
+			}
+			for name, v := range tc.required {
+				empty[name] = v
+			}
+			nullConfig := r.config(tc.required)
+			emptyConfig := r.config(empty)
+			timeoutsConfig := r.config(withTimeouts(r, tc.required))
+
+			r.apply("create with nulls", nullConfig)
+			r.expectNoChanges("after create with nulls", nullConfig)
+			r.apply("update to empty", emptyConfig)
+			r.expectNoChanges("after update to empty", emptyConfig)
+			r.apply("update to nulls", nullConfig)
+			r.expectNoChanges("after update to nulls", nullConfig)
+			r.apply("update timeouts only", timeoutsConfig)
+			r.expectNoChanges("after update timeouts only", timeoutsConfig)
+			r.destroy("destroy")
+
+			r.apply("create with empty", emptyConfig)
+			r.expectNoChanges("after create with empty", emptyConfig)
+			r.destroy("destroy empty")
+
+			for _, name := range tc.rejectEmpty {
+				attrs := map[string]interface{}{}
+				for k, v := range tc.required {
+					attrs[k] = v
+				}
+				setAttribute(attrs, name, emptyOptionals(r.schema.Block))
+				if msg := r.validate(r.config(attrs)); msg == "" {
+					t.Errorf("%s = empty: expected an error", name)
+				}
+			}
+		})
+	}
+}
+
+// withTimeouts returns attrs with every timeout in the timeouts block set.
+func withTimeouts(r *tfResource, attrs map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, v := range attrs {
+		out[k] = v
+	}
+	block := map[string]interface{}{}
+	for name := range r.typ.AttributeTypes["timeouts"].(tftypes.Object).AttributeTypes {
+		block[name] = "45m"
+	}
+	out["timeouts"] = block
+	return out
+}
+
+// deleteAttribute removes the possibly nested attribute "block.attr".
+func deleteAttribute(attrs map[string]interface{}, name string) {
+	parts := strings.Split(name, ".")
+	for _, p := range parts[:len(parts)-1] {
+		attrs = attrs[p].(map[string]interface{})
+	}
+	delete(attrs, parts[len(parts)-1])
+}
+
+// setAttribute copies the possibly nested attribute name from src to attrs.
+func setAttribute(attrs map[string]interface{}, name string, src map[string]interface{}) {
+	parts := strings.Split(name, ".")
+	for _, p := range parts[:len(parts)-1] {
+		if _, ok := attrs[p]; !ok {
+			attrs[p] = map[string]interface{}{}
+		}
+		attrs = attrs[p].(map[string]interface{})
+		src = src[p].(map[string]interface{})
+	}
+	attrs[parts[len(parts)-1]] = src[parts[len(parts)-1]]
+}
+
+// TestOmittedBooleansKeepPlannedValues covers Umbrella leaving optional
+// booleans out of rule and ruleset responses: the planned values must be
+// kept rather than replaced with false.
+func TestOmittedBooleansKeepPlannedValues(t *testing.T) {
+	for typeName, attrs := range map[string]map[string]interface{}{
+		"umbrella_rule":    {"ruleset_id": "10", "name": "allow", "action": "ALLOW", "rank": 1},
+		"umbrella_ruleset": {"name": "default", "saml_enabled": true, "ssl_decryption_enabled": true},
+	} {
+		typeName, attrs := typeName, attrs
+		t.Run(typeName, func(t *testing.T) {
+			f := newFakeUmbrella(t)
+			for _, field := range []string{"enabled", "samlEnabled", "sslDecryptionEnabled"} {
+				f.omit[field] = true
+			}
+			r := newTFHarness(t, f.api.client).resource(typeName)
+			config := r.config(attrs)
+
+			r.apply("create", config)
+			r.expectNoChanges("after create", config)
+
+			var state map[string]tftypes.Value
+			if err := r.state.As(&state); err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range []string{"enabled", "saml_enabled", "ssl_decryption_enabled"} {
+				v, ok := state[name]
+				if !ok {
+					continue
+				}
+				var b bool
+				if err := v.As(&b); err != nil || !b {
+					t.Errorf("%s = %v, want the planned true", name, v)
+				}
+			}
+			r.destroy("destroy")
+		})
+	}
+}
+
+// TestOrgIDOverrideUsesChildOrganisation manages objects in a child
+// organisation: every call must address the child and use a token requested
+// for it, which the fake enforces.
+func TestOrgIDOverrideUsesChildOrganisation(t *testing.T) {
+	for typeName, attrs := range map[string]map[string]interface{}{
+		"umbrella_destination_list": {"name": "blocked", "type": "DOMAIN", "destinations": []string{"example.com"}},
+		"umbrella_destination":      {"destination_list_id": "100", "destination": "example.com"},
+		"umbrella_ruleset":          {"name": "default"},
+		"umbrella_tunnel": {
+			"name": "branch", "site_origin_id": 1, "device_ip": "198.51.100.7",
+			"pre_shared_key": "secret", "local_networks": []string{"10.1.0.0/16"},
+		},
+	} {
+		typeName, attrs := typeName, attrs
+		t.Run(typeName, func(t *testing.T) {
+			f := newFakeUmbrella(t)
+			f.seed(map[string]interface{}{"id": 100, "name": "blocked"}, destListPath+"/100", "5678")
+			r := newTFHarness(t, f.api.client).resource(typeName)
+			attrs["org_id"] = "5678"
+			config := r.config(attrs)
+
+			r.apply("create", config)
+			r.expectNoChanges("after create", config)
+			r.destroy("destroy")
+
+			if got := f.received(http.MethodPost, `/organizations/5678/`); len(got) == 0 {
+				t.Error("nothing was created in the child organisation")
+			}
+			for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+				if got := f.received(method, `/organizations/1234/`); len(got) != 0 {
+					t.Errorf("%s sent to the parent organisation: %s", method, got[0].path)
+				}
+			}
+		})
+	}
+}
+
+func TestImportWithOrgID(t *testing.T) {
+	f := newFakeUmbrella(t)
+	f.seed(map[string]interface{}{"id": 100, "name": "parent", "type": "DOMAIN"}, destListPath+"/100", "1234")
+	f.seed(map[string]interface{}{"id": 200, "name": "child", "type": "URL"}, destListPath+"/200", "5678")
+	h := newTFHarness(t, f.api.client)
+
+	for id, want := range map[string]map[string]interface{}{
+		"100":      {"org_id": "1234", "name": "parent", "type": "DOMAIN"},
+		"5678/200": {"org_id": "5678", "name": "child", "type": "URL"},
+	} {
+		r := h.resource("umbrella_destination_list")
+		r.importState("import "+id, id)
+		for name, v := range want {
+			if got := r.stateString(name); got != v {
+				t.Errorf("import %s: %s = %q, want %q", id, name, got, v)
+			}
+		}
+		r.expectNoChanges("after import "+id, r.config(want))
+	}
+	if got := f.received(http.MethodGet, `^`+fmt.Sprintf(destListPath, "5678")+`/200$`); len(got) == 0 {
+		t.Error("the child organisation's list was not read")
+	}
+
+	resp, err := h.server.ImportResourceState(h.ctx, &tfprotov6.ImportResourceStateRequest{TypeName: "umbrella_destination_list", ID: "/200"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg := diagnosticErrors(resp.Diagnostics); !strings.Contains(msg, "<org_id>/<id>") {
+		t.Errorf("import /200: got %q, want an error describing the ID format", msg)
+	}
+}
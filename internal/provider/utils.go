@@ -2,9 +2,21 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -43,6 +55,15 @@ func diffSlices(old, new []string) (toAdd, toDel []string) {
 	return
 }
 
+func setToInt64Slice(ctx context.Context, v types.Set, diags *diag.Diagnostics) []int64 {
+	if v.IsNull() || v.IsUnknown() {
+		return []int64{}
+	}
+	var out []int64
+	diags.Append(v.ElementsAs(ctx, &out, false)...)
+	return out
+}
+
 // Helper function to compare string slices
 func stringSlicesEqual(a, b []string) bool {
 	if len(a) != len(b) {
@@ -60,6 +81,16 @@ func stringSlicesEqual(a, b []string) bool {
 	return true
 }
 
+// containsString reports whether want is one of vals.
+func containsString(vals []string, want string) bool {
+	for _, v := range vals {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
 // Helper function to convert string slice to attr.Value slice
 func stringSliceToAttrValues(slice []string) []attr.Value {
 	elems := []attr.Value{}
@@ -68,3 +99,160 @@ func stringSliceToAttrValues(slice []string) []attr.Value {
 	}
 	return elems
 }
+
+// stringValueOrNull keeps an optional string null when it was null before and
+// the API returned an empty value, so "" and null do not flip-flop in state.
+func stringValueOrNull(prior types.String, v string) types.String {
+	if prior.IsNull() && v == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(v)
+}
+
+// boolValueOrPrior returns the API's value for an optional boolean, keeping
+// the planned or prior value when the API omitted the field (nil).
+func boolValueOrPrior(prior types.Bool, v *bool) types.Bool {
+	if v == nil {
+		return prior
+	}
+	return types.BoolValue(*v)
+}
+
+// stringSetValue builds a string set from API values, keeping the attribute
+// null when it was null before and the API returned nothing.
+func stringSetValue(prior types.Set, vals []string) types.Set {
+	if prior.IsNull() && len(vals) == 0 {
+		return types.SetNull(types.StringType)
+	}
+	set, _ := types.SetValue(types.StringType, stringSliceToAttrValues(vals))
+	return set
+}
+
+// int64SetValue is the int64 counterpart of stringSetValue.
+func int64SetValue(prior types.Set, vals []int64) types.Set {
+	if prior.IsNull() && len(vals) == 0 {
+		return types.SetNull(types.Int64Type)
+	}
+	elems := []attr.Value{}
+	for _, v := range vals {
+		elems = append(elems, types.Int64Value(v))
+	}
+	set, _ := types.SetValue(types.Int64Type, elems)
+	return set
+}
+
+// orgIDAttribute is the per-resource organisation override used for managing
+// child organisations from a parent (MSP / Multi-Org) provider. Objects cannot
+// move between organisations, so a change forces replacement.
+func orgIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Umbrella organisation ID to manage this object in. Defaults to the provider's org_id.",
+		Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// importStateWithOrgID accepts either "<id>" or "<org_id>/<id>" so objects in
+// child organisations can be imported with the org_id override set.
+func importStateWithOrgID(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgID, id, found := strings.Cut(req.ID, "/")
+	if !found {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+	if orgID == "" || id == "" {
+		resp.Diagnostics.AddError("Import failed", fmt.Sprintf("expected import ID \"<id>\" or \"<org_id>/<id>\", got %q", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), orgID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// dataSourceOrgIDAttribute is the data source counterpart of orgIDAttribute.
+func dataSourceOrgIDAttribute() dsschema.StringAttribute {
+	return dsschema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Umbrella organisation ID to query. Defaults to the provider's org_id.",
+		Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+	}
+}
+
+// cidrValidator checks that a string is an IPv4/IPv6 network in CIDR notation
+// with no host bits set, so the value round-trips through the API unchanged.
+type cidrValidator struct{}
+
+func (v cidrValidator) Description(_ context.Context) string {
+	return "value must be a network in CIDR notation, e.g. 10.0.0.0/24"
+}
+
+func (v cidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	ip, ipNet, err := net.ParseCIDR(value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid CIDR", fmt.Sprintf("%q is not in CIDR notation: %s", value, err))
+		return
+	}
+	if !ip.Equal(ipNet.IP) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid CIDR", fmt.Sprintf("%q has host bits set; use %s", value, ipNet.String()))
+	}
+}
+
+// durationValidator checks that a string parses with time.ParseDuration and
+// is positive.
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return `value must be a positive duration such as "72h" or "30m"`
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", err.Error())
+		return
+	}
+	if d <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", fmt.Sprintf("%s is not positive", d))
+	}
+}
+
+// ipAddressValidator checks that a string is a plain IPv4/IPv6 address.
+type ipAddressValidator struct{}
+
+func (v ipAddressValidator) Description(_ context.Context) string {
+	return "value must be an IP address, e.g. 10.0.0.53"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	if net.ParseIP(value) == nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IP address", fmt.Sprintf("%q is not an IP address", value))
+	}
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestStringValueOrNull(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		prior types.String
+		v     string
+		want  types.String
+	}{
+		{"null stays null when API is empty", types.StringNull(), "", types.StringNull()},
+		{"null picks up API value", types.StringNull(), "x", types.StringValue("x")},
+		{"empty stays empty", types.StringValue(""), "", types.StringValue("")},
+		{"value cleared by API", types.StringValue("x"), "", types.StringValue("")},
+		{"value updated by API", types.StringValue("x"), "y", types.StringValue("y")},
+		{"unknown resolved to empty", types.StringUnknown(), "", types.StringValue("")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := stringValueOrNull(tc.prior, tc.v); !got.Equal(tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBoolValueOrPrior(t *testing.T) {
+	yes, no := true, false
+	for _, tc := range []struct {
+		name  string
+		prior types.Bool
+		v     *bool
+		want  types.Bool
+	}{
+		{"omitted keeps planned true", types.BoolValue(true), nil, types.BoolValue(true)},
+		{"omitted keeps null", types.BoolNull(), nil, types.BoolNull()},
+		{"false from API", types.BoolValue(true), &no, types.BoolValue(false)},
+		{"true from API", types.BoolNull(), &yes, types.BoolValue(true)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := boolValueOrPrior(tc.prior, tc.v); !got.Equal(tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStringSetValue(t *testing.T) {
+	empty := types.SetValueMust(types.StringType, []attr.Value{})
+	ab := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("a"), types.StringValue("b")})
+	for _, tc := range []struct {
+		name  string
+		prior types.Set
+		vals  []string
+		want  types.Set
+	}{
+		{"null stays null when API is empty", types.SetNull(types.StringType), nil, types.SetNull(types.StringType)},
+		{"null stays null when API is an empty list", types.SetNull(types.StringType), []string{}, types.SetNull(types.StringType)},
+		{"null picks up API values", types.SetNull(types.StringType), []string{"b", "a"}, ab},
+		{"empty stays empty", empty, nil, empty},
+		{"values cleared by API", ab, nil, empty},
+		{"unknown resolved to empty", types.SetUnknown(types.StringType), nil, empty},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := stringSetValue(tc.prior, tc.vals); !got.Equal(tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInt64SetValue(t *testing.T) {
+	empty := types.SetValueMust(types.Int64Type, []attr.Value{})
+	twelve := types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(1), types.Int64Value(2)})
+	for _, tc := range []struct {
+		name  string
+		prior types.Set
+		vals  []int64
+		want  types.Set
+	}{
+		{"null stays null when API is empty", types.SetNull(types.Int64Type), nil, types.SetNull(types.Int64Type)},
+		{"null picks up API values", types.SetNull(types.Int64Type), []int64{2, 1}, twelve},
+		{"empty stays empty", empty, nil, empty},
+		{"values cleared by API", twelve, nil, empty},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := int64SetValue(tc.prior, tc.vals); !got.Equal(tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCIDRValidator(t *testing.T) {
+	for _, tc := range []struct {
+		value types.String
+		valid bool
+	}{
+		{types.StringValue("10.0.0.0/24"), true},
+		{types.StringValue("2001:db8::/32"), true},
+		{types.StringValue("192.0.2.7/32"), true},
+		{types.StringNull(), true},
+		{types.StringUnknown(), true},
+		{types.StringValue("10.0.0.1/24"), false},
+		{types.StringValue("10.0.0.0"), false},
+		{types.StringValue("10.0.0.0/33"), false},
+		{types.StringValue("corp.example.com"), false},
+	} {
+		req := validator.StringRequest{Path: path.Root("cidr"), ConfigValue: tc.value}
+		var resp validator.StringResponse
+		cidrValidator{}.ValidateString(context.Background(), req, &resp)
+		if got := !resp.Diagnostics.HasError(); got != tc.valid {
+			t.Errorf("%s: valid = %t, want %t (%v)", tc.value, got, tc.valid, resp.Diagnostics)
+		}
+	}
+}
@@ -8,11 +8,15 @@ import (
 	"github.com/mantisec/terraform-provider-umbrella/internal/provider"
 )
 
+// version is stamped into release builds by goreleaser (.goreleaser.yml, via
+// -X main.version); local builds report "dev".
+var version = "dev"
+
 // -----------------------------------------------------------------------------
 // Provider entry point
 // -----------------------------------------------------------------------------
 func main() {
-	err := providerserver.Serve(context.Background(), provider.NewProvider, providerserver.ServeOpts{})
+	err := providerserver.Serve(context.Background(), provider.NewProvider(version), providerserver.ServeOpts{})
 	if err != nil {
 		log.Fatal(err.Error())
 	}